@@ -0,0 +1,238 @@
+package address
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// DefaultThreshold is the similarity from which two places are taken to be the same by Dedupe
+const DefaultThreshold = 0.85
+
+// weights of the components compared by Similarity, shared out among those either address has
+var weights = map[Field]float64{HouseNumber: 0.25, Street: 0.35, Postcode: 0.2, City: 0.2}
+
+// oneSided is the part of its weight a component only one address has counts as a mismatch, less than the whole
+// as it may have been left out rather than be different
+const oneSided = 0.5
+
+// proximityScale is the distance in meters at which the proximity of two places has dropped to about a third
+const proximityScale = 250
+
+// Place is an address with the location it was geocoded at or from, if known
+type Place struct {
+	Address  geo.Address
+	Location *geo.Location
+}
+
+// Similarity returns from 0 to 1 how likely a and b are the same place by their house number, street, postcode and
+// city, after normalising case, accents, punctuation and street type abbreviations. A component only one of them has
+// counts as a partial mismatch, so that a street address is not the same as the centroid of its city.
+// Addresses in different countries are 0, and those with none of these components are compared by FormattedAddress
+func Similarity(a, b geo.Address) float64 {
+	ca, oka := geo.LookupCountry(a.CountryCode)
+	cb, okb := geo.LookupCountry(b.CountryCode)
+	if oka && okb && ca != cb {
+		return 0
+	}
+	code := ca.Alpha2
+	if !oka {
+		code = cb.Alpha2
+	}
+
+	score, total := 0.0, 0.0
+	add := func(f Field, x, y string, similarity func(string, string) float64) {
+		x, y = normalize(x), normalize(y)
+		switch {
+		case x == "" && y == "":
+			return
+		case x == "" || y == "":
+			total += oneSided * weights[f]
+			return
+		}
+		score += weights[f] * similarity(x, y)
+		total += weights[f]
+	}
+	add(HouseNumber, a.HouseNumber, b.HouseNumber, houseNumberSimilarity)
+	add(Street, expandStreet(a.Street, code), expandStreet(b.Street, code), textSimilarity)
+	add(Postcode, a.Postcode, b.Postcode, postcodeSimilarity)
+	add(City, a.City, b.City, textSimilarity)
+	if total == 0 {
+		x, y := normalize(a.FormattedAddress), normalize(b.FormattedAddress)
+		if x == "" || y == "" {
+			return 0
+		}
+		return textSimilarity(x, y)
+	}
+	return score / total
+}
+
+// Compare returns from 0 to 1 how likely p and o are the same place, from the Similarity of their addresses
+// and, when both have a location, how close they are
+func Compare(p, o Place) float64 {
+	s := Similarity(p.Address, o.Address)
+	if p.Location == nil || o.Location == nil {
+		return s
+	}
+	proximity := math.Exp(-p.Location.Distance(*o.Location) / proximityScale)
+	return 0.7*s + 0.3*proximity
+}
+
+// Cluster groups the indices of places which are the same place, as places with a Compare of at least threshold
+// and any place the same as one of a group, each group and the groups in the order of places
+func Cluster(places []Place, threshold float64) [][]int {
+	parent := make([]int, len(places))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range places {
+		for j := i + 1; j < len(places); j++ {
+			if ri, rj := root(i), root(j); ri != rj && Compare(places[i], places[j]) >= threshold {
+				// the first place of a group is its root
+				if ri < rj {
+					parent[rj] = ri
+				} else {
+					parent[ri] = rj
+				}
+			}
+		}
+	}
+
+	var clusters [][]int
+	index := map[int]int{}
+	for i := range places {
+		r := root(i)
+		if _, ok := index[r]; !ok {
+			index[r] = len(clusters)
+			clusters = append(clusters, nil)
+		}
+		clusters[index[r]] = append(clusters[index[r]], i)
+	}
+	return clusters
+}
+
+// Dedupe returns the first of each group of places which are the same place by Cluster with threshold
+func Dedupe(places []Place, threshold float64) []Place {
+	var unique []Place
+	for _, c := range Cluster(places, threshold) {
+		unique = append(unique, places[c[0]])
+	}
+	return unique
+}
+
+// accents are replaced with the letters they are on, for the accented letters of the countries with rules
+var accents = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ä", "a", "ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e", "î", "i", "ï", "i",
+	"ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "ÿ", "y", "œ", "oe", "æ", "ae", "ß", "ss", "ё", "е",
+)
+
+// normalize returns s in lower case without accents and punctuation, with single spaces between words
+func normalize(s string) string {
+	s = accents.Replace(strings.ToLower(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// expandStreet returns street with the abbreviations of street types in the language of countryCode written out
+func expandStreet(street, countryCode string) string {
+	lang := language(countryCode)
+	words := strings.Fields(strings.ToLower(street))
+	for i, w := range words {
+		w = strings.TrimRight(w, ".,")
+		for full, a := range streetAbbreviations[lang] {
+			a = strings.TrimRight(strings.ToLower(a), ".")
+			switch {
+			case w == a:
+				words[i] = full
+			case lang == "de" && strings.HasSuffix(w, a) && len(w) > len(a):
+				words[i] = w[:len(w)-len(a)] + full
+			}
+		}
+	}
+	// both spellings of German streets
+	return strings.Replace(strings.Join(words, " "), "strasse", "straße", -1)
+}
+
+// houseNumberSimilarity is 1 for the same numbers, and less for the same number with another suffix, as 12 and 12a
+func houseNumberSimilarity(a, b string) float64 {
+	a, b = strings.Replace(a, " ", "", -1), strings.Replace(b, " ", "", -1)
+	if a == b {
+		return 1
+	}
+	if leadingDigits(a) != "" && leadingDigits(a) == leadingDigits(b) {
+		return 0.6
+	}
+	return 0
+}
+
+func leadingDigits(s string) string {
+	i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+	if i < 0 {
+		return s
+	}
+	return s[:i]
+}
+
+// postcodeSimilarity is 1 for the same postcodes, and less when one extends the other, as ZIP and ZIP+4 codes do
+func postcodeSimilarity(a, b string) float64 {
+	a, b = strings.Replace(a, " ", "", -1), strings.Replace(b, " ", "", -1)
+	switch {
+	case a == b:
+		return 1
+	case strings.HasPrefix(a, b) || strings.HasPrefix(b, a):
+		return 0.9
+	}
+	return 0
+}
+
+// textSimilarity is 1 less the edit distance between a and b relative to the longer of them
+func textSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longer := len(ra)
+	if len(rb) > longer {
+		longer = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longer)
+}
+
+func levenshtein(a, b []rune) int {
+	previous, current := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := range a {
+		current[0] = i + 1
+		for j := range b {
+			cost := 1
+			if a[i] == b[j] {
+				cost = 0
+			}
+			current[j+1] = minimum(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minimum(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,63 @@
+package address_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, address.Similarity(melbourne, melbourne))
+	assert.Equal(t, 1.0, address.Similarity(melbourne, geo.Address{
+		HouseNumber: "64", Street: "Elizabeth St.", City: "MELBOURNE", Postcode: "3000", CountryCode: "AUS",
+	}))
+	assert.Equal(t, 1.0, address.Similarity(berlin, geo.Address{
+		HouseNumber: "43", Street: "Friedrichstr.", City: "Berlin", Postcode: "10117", CountryCode: "DE",
+	}))
+
+	// components only one address has are partial mismatches
+	s := address.Similarity(berlin, geo.Address{Street: "Friedrichstrasse", HouseNumber: "43"})
+	assert.True(t, s > 0.5 && s < address.DefaultThreshold, s)
+	s = address.Similarity(geo.Address{HouseNumber: "64", Street: "Elizabeth St", City: "Melbourne"}, geo.Address{City: "Melbourne"})
+	assert.True(t, s < 0.5, s)
+
+	// a house number suffix, ZIP+4 and a typo
+	s = address.Similarity(mountainView, geo.Address{
+		HouseNumber: "1600A", Street: "Amphitheater Pkwy", City: "Mountain View", Postcode: "94043-1351",
+	})
+	assert.True(t, s > 0.8 && s < 1, s)
+
+	assert.True(t, address.Similarity(melbourne, geo.Address{HouseNumber: "66", Street: "Elizabeth Street", City: "Melbourne", Postcode: "3000"}) < 0.8)
+	assert.True(t, address.Similarity(melbourne, geo.Address{HouseNumber: "64", Street: "Collins Street", City: "Melbourne", Postcode: "3000"}) < 0.85)
+	assert.Equal(t, 0.0, address.Similarity(melbourne, geo.Address{HouseNumber: "64", Street: "Elizabeth Street", CountryCode: "US"}))
+
+	assert.Equal(t, 1.0, address.Similarity(geo.Address{FormattedAddress: "Tour Eiffel, Paris"}, geo.Address{FormattedAddress: "tour eiffel paris"}))
+	assert.Equal(t, 0.0, address.Similarity(geo.Address{}, geo.Address{}))
+}
+
+func TestCompare(t *testing.T) {
+	here := geo.Location{Lat: -37.8136, Lng: 144.9631}
+	near, _ := here.Destination(20, 90)
+	far, _ := here.Destination(5000, 90)
+
+	same := address.Compare(address.Place{Address: melbourne, Location: &here}, address.Place{Address: melbourne, Location: &near})
+	assert.True(t, same > 0.95, same)
+	distant := address.Compare(address.Place{Address: melbourne, Location: &here}, address.Place{Address: melbourne, Location: &far})
+	assert.InDelta(t, 0.7, distant, 0.01)
+	assert.Equal(t, 1.0, address.Compare(address.Place{Address: melbourne}, address.Place{Address: melbourne, Location: &far}))
+}
+
+func TestDedupe(t *testing.T) {
+	places := []address.Place{
+		{Address: melbourne},
+		{Address: berlin},
+		{Address: geo.Address{HouseNumber: "64", Street: "Elizabeth St", City: "Melbourne", Postcode: "3000"}},
+		{Address: mountainView},
+		{Address: geo.Address{HouseNumber: "43", Street: "Friedrichstr.", City: "Berlin", Postcode: "10117", CountryCode: "DE"}},
+	}
+	assert.Equal(t, [][]int{{0, 2}, {1, 4}, {3}}, address.Cluster(places, address.DefaultThreshold))
+	assert.Equal(t, []address.Place{places[0], places[1], places[3]}, address.Dedupe(places, address.DefaultThreshold))
+	assert.Nil(t, address.Dedupe(nil, address.DefaultThreshold))
+}
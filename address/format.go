@@ -0,0 +1,98 @@
+package address
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// templates lay out the fields of addresses by country, in the style of the OpenCage address-formatting templates.
+// A placeholder names a field of geo.Address, or several separated by | of which the first not empty is used
+var templates = map[string]string{
+	"default": "{{Street}} {{HouseNumber}}\n{{Postcode}} {{City|Suburb}}\n{{Country}}",
+	"AU":      "{{HouseNumber}} {{Street}}\n{{Suburb|City}} {{State}} {{Postcode}}\n{{Country}}",
+	"BR":      "{{Street}}, {{HouseNumber}}\n{{Suburb}}\n{{City}} - {{State}}\n{{Postcode}}\n{{Country}}",
+	"CA":      "{{HouseNumber}} {{Street}}\n{{City|Suburb}}, {{State}} {{Postcode}}\n{{Country}}",
+	"ES":      "{{Street}}, {{HouseNumber}}\n{{Postcode}} {{City|Suburb}}\n{{State}}\n{{Country}}",
+	"FR":      "{{HouseNumber}} {{Street}}\n{{Postcode}} {{City|Suburb}}\n{{Country}}",
+	"GB":      "{{HouseNumber}} {{Street}}\n{{Suburb}}\n{{City}}\n{{Postcode}}\n{{Country}}",
+	"IE":      "{{HouseNumber}} {{Street}}\n{{Suburb}}\n{{City}}\n{{County}}\n{{Postcode}}\n{{Country}}",
+	"IN":      "{{HouseNumber}}, {{Street}}\n{{Suburb}}\n{{City}} - {{Postcode}}\n{{State}}\n{{Country}}",
+	"IT":      "{{Street}}, {{HouseNumber}}\n{{Postcode}} {{City|Suburb}} {{County}}\n{{Country}}",
+	"NZ":      "{{HouseNumber}} {{Street}}\n{{Suburb}}\n{{City}} {{Postcode}}\n{{Country}}",
+	"RU":      "{{Street}}, {{HouseNumber}}\n{{City|Suburb}}\n{{State}}\n{{Country}}\n{{Postcode}}",
+	"US":      "{{HouseNumber}} {{Street}}\n{{City|Suburb}}, {{State}} {{Postcode}}\n{{Country}}",
+}
+
+// countries sharing the template of another
+func init() {
+	for code, like := range map[string]string{
+		"BE": "FR", "LU": "FR", "MC": "FR", "PR": "US", "GG": "GB", "IM": "GB", "JE": "GB",
+	} {
+		templates[code] = templates[like]
+	}
+}
+
+var (
+	placeholder = regexp.MustCompile(`\{\{([A-Za-z|]+)\}\}`)
+	// punctuation left around fields which are empty
+	danglingPunctuation = regexp.MustCompile(`\s*,(?:\s*,)+|\s+,`)
+)
+
+// Formatter renders addresses by the template of their country
+type Formatter struct {
+	SingleLine  bool   // lines joined with commas
+	Abbreviate  bool   // street types abbreviated, as in "St" for "Street"
+	CountryCode string // ISO 3166-1 alpha-2 code of the template for addresses without one
+}
+
+// Format returns a on several lines by the template of its country
+func Format(a geo.Address) string { return Formatter{}.Format(a) }
+
+// FormatLine returns a on a single line by the template of its country
+func FormatLine(a geo.Address) string { return Formatter{SingleLine: true}.Format(a) }
+
+// Format returns a by the template of its country, or FormattedAddress if a has no other field
+func (f Formatter) Format(a geo.Address) string {
+	code := a.CountryCode
+	if code == "" {
+		code = f.CountryCode
+	}
+	if c, ok := geo.LookupCountry(code); ok {
+		code = c.Alpha2
+	}
+	template, ok := templates[code]
+	if !ok {
+		template = templates["default"]
+	}
+	if f.Abbreviate {
+		a.Street = abbreviateStreet(a.Street, code)
+	}
+
+	p := Parsed{Address: a}
+	rendered := placeholder.ReplaceAllStringFunc(template, func(s string) string {
+		for _, name := range strings.Split(placeholder.FindStringSubmatch(s)[1], "|") {
+			if v := strings.TrimSpace(*p.field(Field(name))); v != "" {
+				return v
+			}
+		}
+		return ""
+	})
+
+	var lines []string
+	for _, line := range strings.Split(rendered, "\n") {
+		line = danglingPunctuation.ReplaceAllString(strings.Join(strings.Fields(line), " "), ",")
+		line = strings.Trim(line, " ,-")
+		if line != "" && (len(lines) == 0 || line != lines[len(lines)-1]) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return a.FormattedAddress
+	}
+	if f.SingleLine {
+		return strings.Join(lines, ", ")
+	}
+	return strings.Join(lines, "\n")
+}
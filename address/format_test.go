@@ -0,0 +1,79 @@
+package address_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	melbourne = geo.Address{
+		HouseNumber: "64", Street: "Elizabeth Street", City: "Melbourne", State: "VIC", Postcode: "3000",
+		Country: "Australia", CountryCode: "AU",
+	}
+	mountainView = geo.Address{
+		HouseNumber: "1600", Street: "Amphitheatre Parkway", City: "Mountain View", State: "CA", Postcode: "94043",
+		Country: "United States of America", CountryCode: "us",
+	}
+	berlin = geo.Address{
+		HouseNumber: "43", Street: "Friedrichstraße", City: "Berlin", State: "Berlin", Postcode: "10117",
+		Country: "Deutschland", CountryCode: "DE",
+	}
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "64 Elizabeth Street\nMelbourne VIC 3000\nAustralia", address.Format(melbourne))
+	assert.Equal(t, "1600 Amphitheatre Parkway\nMountain View, CA 94043\nUnited States of America", address.Format(mountainView))
+	assert.Equal(t, "Friedrichstraße 43\n10117 Berlin\nDeutschland", address.Format(berlin))
+	assert.Equal(t, address.Format(berlin), address.Format(geo.Address{HouseNumber: "43", Street: "Friedrichstraße", City: "Berlin", Postcode: "10117", Country: "Deutschland", CountryCode: "DEU"}))
+	assert.Equal(t, "5 Quai Anatole France\n75007 Paris\nFrance", address.Format(geo.Address{
+		HouseNumber: "5", Street: "Quai Anatole France", Postcode: "75007", City: "Paris", County: "Paris",
+		State: "Île-de-France", Country: "France", CountryCode: "FR",
+	}))
+	assert.Equal(t, "ул. Тверская, 7\nМосква\nРоссия\n125009", address.Format(geo.Address{
+		HouseNumber: "7", Street: "ул. Тверская", City: "Москва", Postcode: "125009", Country: "Россия", CountryCode: "RU",
+	}))
+}
+
+func TestFormatMissingFields(t *testing.T) {
+	assert.Equal(t, "Mountain View, CA\nUnited States of America",
+		address.Format(geo.Address{City: "Mountain View", State: "CA", Country: "United States of America", CountryCode: "US"}))
+	assert.Equal(t, "Springfield, 12345", address.FormatLine(geo.Address{City: "Springfield", Postcode: "12345", CountryCode: "US"}))
+	assert.Equal(t, "Main Street 1, 1234 Vaduz", address.FormatLine(geo.Address{Street: "Main Street", HouseNumber: "1", Postcode: "1234", City: "Vaduz"}))
+	assert.Equal(t, "somewhere", address.Format(geo.Address{FormattedAddress: "somewhere"}))
+}
+
+func TestFormatter(t *testing.T) {
+	assert.Equal(t, "64 Elizabeth St, Melbourne VIC 3000, Australia",
+		address.Formatter{SingleLine: true, Abbreviate: true}.Format(melbourne))
+	assert.Equal(t, "Friedrichstr. 43, 10117 Berlin, Deutschland", address.Formatter{SingleLine: true, Abbreviate: true}.Format(berlin))
+	assert.Equal(t, "12 Av. des Champs-Élysées\n75008 Paris", address.Formatter{Abbreviate: true, CountryCode: "FR"}.Format(geo.Address{
+		HouseNumber: "12", Street: "Avenue des Champs-Élysées", Postcode: "75008", City: "Paris",
+	}))
+	// a street named after its type alone is left as it is
+	assert.Equal(t, "1 Broadway, New York, NY 10004", address.Formatter{SingleLine: true, Abbreviate: true}.Format(geo.Address{
+		HouseNumber: "1", Street: "Broadway", City: "New York", State: "NY", Postcode: "10004", CountryCode: "US",
+	}))
+}
+
+func TestAbbreviateStreetType(t *testing.T) {
+	for _, c := range []struct{ countryCode, street, expected string }{
+		{"US", "South Street", "South St"},
+		{"AU", "Lane Cove Road", "Lane Cove Rd"},
+		{"GB", "Old Court Road", "Old Court Rd"},
+		{"US", "North Main Street", "N Main St"},
+		{"US", "Main Street West", "Main St W"},
+		{"FR", "Rue de la Place", "Rue de la Place"},
+		{"FR", "Place de la Concorde", "Pl. de la Concorde"},
+		{"FR", "Rue Saint Denis", "Rue St Denis"},
+		{"DE", "Berliner Straße", "Berliner Str."},
+		{"DE", "Platz der Republik", "Platz der Republik"},
+		{"RU", "улица Тверская", "ул. Тверская"},
+		{"RU", "Ленинградский проспект", "Ленинградский пр-т"},
+	} {
+		a := geo.Address{Street: c.street, CountryCode: c.countryCode}
+		assert.Equal(t, c.expected, address.Formatter{Abbreviate: true}.Format(a), c.street)
+	}
+}
@@ -0,0 +1,438 @@
+// Package address parses free-form addresses into geo.Address components, formats them by the conventions of their
+// country, and compares them to find those of the same place
+package address
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// Field is a component of geo.Address
+type Field string
+
+// Fields of geo.Address other than FormattedAddress
+const (
+	HouseNumber   Field = "HouseNumber"
+	Street        Field = "Street"
+	Suburb        Field = "Suburb"
+	City          Field = "City"
+	Postcode      Field = "Postcode"
+	State         Field = "State"
+	StateDistrict Field = "StateDistrict"
+	County        Field = "County"
+	Country       Field = "Country"
+	CountryCode   Field = "CountryCode"
+)
+
+// ErrEmptyAddress occurs when parsing a string with no address in it
+var ErrEmptyAddress = errors.New("empty address")
+
+// Parsed is an address parsed from a string, with the confidence from 0 to 1 of each field found
+type Parsed struct {
+	geo.Address
+	Confidence map[Field]float64
+}
+
+func (p *Parsed) field(f Field) *string {
+	switch f {
+	case HouseNumber:
+		return &p.HouseNumber
+	case Street:
+		return &p.Street
+	case Suburb:
+		return &p.Suburb
+	case City:
+		return &p.City
+	case Postcode:
+		return &p.Postcode
+	case State:
+		return &p.State
+	case StateDistrict:
+		return &p.StateDistrict
+	case County:
+		return &p.County
+	case Country:
+		return &p.Country
+	case CountryCode:
+		return &p.CountryCode
+	default:
+		return new(string)
+	}
+}
+
+// set sets field f to v with confidence, unless v is empty or f is already set
+func (p *Parsed) set(f Field, v string, confidence float64) {
+	v = strings.Trim(v, " ,")
+	if v == "" || *p.field(f) != "" {
+		return
+	}
+	*p.field(f) = v
+	p.Confidence[f] = confidence
+}
+
+// Parse splits s into address fields by the rules of the country it names, or else the country its postcode,
+// state or script suggests, or else rules common to most countries
+func Parse(s string) (*Parsed, error) { return ParseCountry(s, "") }
+
+// ParseCountry splits s into address fields by the rules of the country of the ISO 3166-1 alpha-2 countryCode,
+// or as Parse does if it is empty
+func ParseCountry(s, countryCode string) (*Parsed, error) {
+	parts := splitParts(s)
+	if len(parts) == 0 {
+		return nil, ErrEmptyAddress
+	}
+	p := &Parsed{Address: geo.Address{FormattedAddress: strings.Join(strings.Fields(s), " ")}, Confidence: map[Field]float64{}}
+
+	// the country is named in the first or the last part, unless it is a code which is also a state,
+	// as "DE" is for Delaware, told apart below
+	ambiguous := -1
+	for _, i := range []int{len(parts) - 1, 0} {
+		name := strings.ToLower(strings.TrimRight(parts[i], "."))
+		if code, ok := countries[name]; ok && len(parts) > 1 {
+			if isState(name) {
+				if ambiguous < 0 {
+					ambiguous = i
+				}
+				continue
+			}
+			p.set(Country, parts[i], 1)
+			if code == "GB" && ukNations[strings.ToLower(parts[i])] {
+				p.Country = ""
+				delete(p.Confidence, Country)
+				p.set(State, parts[i], 0.9)
+			}
+			if countryCode == "" {
+				countryCode = code
+				p.set(CountryCode, code, 1)
+			}
+			parts = append(parts[:i], parts[i+1:]...)
+			break
+		}
+	}
+
+	countryCode = strings.ToUpper(countryCode)
+	if countryCode == "" {
+		code, confidence := detect(strings.Join(parts, ", "))
+		if code == "" && ambiguous >= 0 {
+			// nothing tells a state, so the code is more likely the country
+			code, confidence = countries[strings.ToLower(strings.TrimRight(parts[ambiguous], "."))], 0.5
+			p.set(Country, parts[ambiguous], confidence)
+			parts = append(parts[:ambiguous], parts[ambiguous+1:]...)
+		}
+		countryCode = code
+		p.set(CountryCode, code, confidence)
+	} else {
+		p.set(CountryCode, countryCode, 1)
+	}
+
+	switch r, ok := countryRules[countryCode]; {
+	case countryCode == "RU":
+		parseRussian(parts, p)
+	case ok:
+		r.parse(parts, p)
+	case countryCode == "":
+		genericRules.parse(parts, p)
+	default:
+		return nil, fmt.Errorf("no address rules for country %q", countryCode)
+	}
+	return p, nil
+}
+
+// splitParts returns the parts of s separated by commas, semicolons or new lines
+func splitParts(s string) []string {
+	var parts []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if part = strings.Join(strings.Fields(part), " "); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// isState tells whether name, in lower case, is a state of a country with rules
+func isState(name string) bool {
+	for _, r := range countryRules {
+		if r.states[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// detect returns the country whose patterns s matches best, with a confidence, or "" if none does, if several do
+// as well, or if s only has a street type, shared by the countries speaking a language, or a postcode, shaped
+// as those of many countries without rules
+func detect(s string) (string, float64) {
+	for _, r := range s {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return "RU", 0.8
+		}
+	}
+	best, score, tie := "", 0, false
+	for _, code := range []string{"AU", "US", "GB", "DE", "FR"} {
+		switch n := countryRules[code].score(s); {
+		case n > score:
+			best, score, tie = code, n, false
+		case n == score:
+			tie = true
+		}
+	}
+	switch {
+	case tie || score < 3:
+		return "", 0
+	case score == 3:
+		return best, 0.6
+	}
+	return best, 0.8
+}
+
+// rules are the patterns of the addresses of a country
+type rules struct {
+	postcode      *regexp.Regexp
+	postcodeFirst bool // the postcode comes before the city, as in "75008 Paris"
+	numberFirst   bool // the house number comes before the street
+	states        map[string]bool
+	streetTypes   map[string]bool // words marking a street, in lower case without a trailing dot
+	streetEndings []string        // endings of street names written as one word, as in "Friedrichstraße"
+}
+
+var (
+	numberFirstPattern = regexp.MustCompile(`(?i)^((?:\d+[A-Z]?\s?/\s?)?\d+[A-Z]?(?:\s?[-–]\s?\d+[A-Z]?)?(?:\s(?:bis|ter|quater)\b)?)\s+(.+)$`)
+	numberLastPattern  = regexp.MustCompile(`(?i)^(.+?)\s+(\d+\s?[A-Z]?(?:\s?[-/–]\s?\d+[A-Z]?)?)$`)
+)
+
+// score tells how much s looks like an address of r, from 0
+func (r rules) score(s string) int {
+	score := 0
+	if r.postcode.MatchString(s) {
+		score++
+	}
+	// a postcode before the city, or at the end of a part
+	for _, part := range splitParts(s) {
+		m := r.postcode.FindStringIndex(part)
+		if m != nil && (r.postcodeFirst && m[1] < len(part) || !r.postcodeFirst && m[0] > 0 && m[1] == len(part)) {
+			score++
+			break
+		}
+	}
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ' ' || r == ',' })
+	for _, w := range words {
+		if r.isStreetWord(w) {
+			score += 2
+			break
+		}
+	}
+	// a state followed by a postcode, as in "VIC 3000", or else in a part of its own
+	for i := 0; i+1 < len(words) && !r.postcodeFirst; i++ {
+		if r.states[words[i]] && r.postcode.MatchString(words[i+1]) {
+			return score + 2
+		}
+	}
+	for _, part := range splitParts(s) {
+		if r.states[strings.ToLower(strings.TrimRight(part, "."))] {
+			return score + 1
+		}
+	}
+	return score
+}
+
+func (r rules) isStreetWord(w string) bool {
+	w = strings.TrimRight(strings.ToLower(w), ".")
+	if r.streetTypes[w] {
+		return true
+	}
+	for _, e := range r.streetEndings {
+		if strings.HasSuffix(w, e) && len(w) > len(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStreet tells whether part has a street type, or a number where r puts the house number
+func (r rules) isStreet(part string) bool {
+	for _, w := range strings.Fields(part) {
+		if r.isStreetWord(w) {
+			return true
+		}
+	}
+	if r.numberFirst {
+		return numberFirstPattern.MatchString(part)
+	}
+	return numberLastPattern.MatchString(part)
+}
+
+// parse sets the fields of p found in parts, the country having been taken out
+func (r rules) parse(parts []string, p *Parsed) {
+	used := make([]bool, len(parts))
+	cityPart := len(parts)
+
+	// the postcode, looked for from the last part, and not in the first part unless it is the only one
+	for i := len(parts) - 1; i >= 0 && (i > 0 || len(parts) == 1); i-- {
+		matches := r.postcode.FindAllStringIndex(parts[i], -1)
+		if len(matches) == 0 {
+			continue
+		}
+		m := matches[len(matches)-1]
+		if r.postcodeFirst {
+			m = matches[0]
+		}
+		p.set(Postcode, parts[i][m[0]:m[1]], 0.95)
+		before, after := strings.TrimSpace(parts[i][:m[0]]), strings.TrimSpace(parts[i][m[1]:])
+		used[i], cityPart = true, i
+
+		if len(parts) == 1 {
+			// a single part with the street, the city and the postcode
+			if r.postcodeFirst {
+				p.set(City, after, 0.8)
+				r.parseStreet(before, p, false)
+			} else {
+				if state, rest := r.splitState(before); state != "" {
+					p.set(State, state, 0.9)
+					before = rest
+				}
+				r.parseStreet(before, p, true)
+			}
+			return
+		}
+
+		rest := strings.TrimSpace(before + " " + after)
+		if state, city := r.splitState(rest); state != "" {
+			p.set(State, state, 0.9)
+			rest = city
+		}
+		p.set(City, rest, 0.85)
+		break
+	}
+
+	// a state in a part of its own, after the street, unless the postcode came with one
+	for i := len(parts) - 1; i > 0 && p.State == ""; i-- {
+		if !used[i] && r.states[strings.ToLower(strings.TrimRight(parts[i], "."))] && !(r.postcodeFirst && i < cityPart) {
+			p.set(State, parts[i], 0.9)
+			used[i] = true
+			if i < cityPart {
+				cityPart = i
+			}
+		}
+	}
+
+	// the street is the first part looking like one, and anything before it a building or a unit
+	street := -1
+	for i := range parts {
+		if !used[i] && i < cityPart && r.isStreet(parts[i]) {
+			street = i
+			break
+		}
+	}
+	if street < 0 && !used[0] && cityPart > 1 {
+		street = 0
+	}
+	if street >= 0 {
+		r.parseStreet(parts[street], p, false)
+		used[street] = true
+	}
+
+	// the city is the last part left before the postcode or state, the others between the street and it suburbs,
+	// bare numbers being neither
+	var left []int
+	for i := street + 1; i < len(parts); i++ {
+		if isNumber(parts[i]) {
+			continue
+		}
+		if !used[i] && i < cityPart || !used[i] && p.City == "" && i > cityPart {
+			left = append(left, i)
+		}
+	}
+	if len(left) > 0 && p.City == "" {
+		confidence := 0.8
+		if cityPart == len(parts) {
+			confidence = 0.5
+		}
+		p.set(City, parts[left[len(left)-1]], confidence)
+		left = left[:len(left)-1]
+	}
+	if len(left) > 0 {
+		p.set(Suburb, strings.Join(partsAt(parts, left), ", "), 0.6)
+	}
+}
+
+// isNumber tells whether s is made of digits only
+func isNumber(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func partsAt(parts []string, indices []int) []string {
+	selected := make([]string, len(indices))
+	for i, j := range indices {
+		selected[i] = parts[j]
+	}
+	return selected
+}
+
+// splitState returns the state at the end of s, as in "Melbourne VIC", and what comes before it
+func (r rules) splitState(s string) (string, string) {
+	words := strings.Fields(s)
+	for n := 3; n >= 1; n-- {
+		if len(words) < n {
+			continue
+		}
+		// the whole of s is a state only where the postcode follows the city
+		if n == len(words) && r.postcodeFirst {
+			continue
+		}
+		state := strings.Join(words[len(words)-n:], " ")
+		if r.states[strings.ToLower(strings.TrimRight(state, "."))] {
+			return state, strings.Join(words[:len(words)-n], " ")
+		}
+	}
+	return "", s
+}
+
+// parseStreet sets the house number and the street of part, which is also followed by the city
+// when withCity is set, as in the single line "1600 Amphitheatre Parkway Mountain View"
+func (r rules) parseStreet(part string, p *Parsed, withCity bool) {
+	if withCity {
+		words := strings.Fields(part)
+		for i := len(words) - 1; i > 0; i-- {
+			if r.isStreetWord(words[i]) {
+				p.set(City, strings.Join(words[i+1:], " "), 0.6)
+				part = strings.Join(words[:i+1], " ")
+				break
+			}
+		}
+	}
+
+	confidence := 0.7
+	for _, w := range strings.Fields(part) {
+		if r.isStreetWord(w) {
+			confidence = 0.9
+		}
+	}
+	first, last := numberFirstPattern.FindStringSubmatch(part), numberLastPattern.FindStringSubmatch(part)
+	switch {
+	case r.numberFirst && first != nil:
+		p.set(HouseNumber, first[1], 0.9)
+		p.set(Street, first[2], confidence)
+	case !r.numberFirst && last != nil:
+		p.set(HouseNumber, last[2], 0.9)
+		p.set(Street, last[1], confidence)
+	case first != nil:
+		p.set(HouseNumber, first[1], 0.6)
+		p.set(Street, first[2], confidence-0.1)
+	case last != nil:
+		p.set(HouseNumber, last[2], 0.6)
+		p.set(Street, last[1], confidence-0.1)
+	default:
+		p.set(Street, part, confidence-0.1)
+	}
+}
@@ -0,0 +1,142 @@
+package address_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for s, expected := range map[string]geo.Address{
+		"64 Elizabeth Street, Melbourne VIC 3000, Australia": {
+			HouseNumber: "64", Street: "Elizabeth Street", City: "Melbourne", State: "VIC", Postcode: "3000",
+			Country: "Australia", CountryCode: "AU",
+		},
+		"Unit 2, 5/64 Elizabeth St, Southbank, Melbourne, Victoria 3000": {
+			HouseNumber: "5/64", Street: "Elizabeth St", Suburb: "Southbank", City: "Melbourne", State: "Victoria",
+			Postcode: "3000", CountryCode: "AU",
+		},
+		"1600 Amphitheatre Parkway, Mountain View, CA 94043, USA": {
+			HouseNumber: "1600", Street: "Amphitheatre Parkway", City: "Mountain View", State: "CA", Postcode: "94043",
+			Country: "USA", CountryCode: "US",
+		},
+		"350 5th Ave, New York, NY 10118, USA": {
+			HouseNumber: "350", Street: "5th Ave", City: "New York", State: "NY", Postcode: "10118",
+			Country: "USA", CountryCode: "US",
+		},
+		"1600 Pennsylvania Ave NW, Washington, DC 20500": {
+			HouseNumber: "1600", Street: "Pennsylvania Ave NW", City: "Washington", State: "DC", Postcode: "20500",
+			CountryCode: "US",
+		},
+		"1600 Amphitheatre Parkway Mountain View CA 94043": {
+			HouseNumber: "1600", Street: "Amphitheatre Parkway", City: "Mountain View", State: "CA", Postcode: "94043",
+			CountryCode: "US",
+		},
+		"10 Downing Street, London SW1A 2AA, United Kingdom": {
+			HouseNumber: "10", Street: "Downing Street", City: "London", Postcode: "SW1A 2AA",
+			Country: "United Kingdom", CountryCode: "GB",
+		},
+		"221B Baker Street, London, NW1 6XE, England": {
+			HouseNumber: "221B", Street: "Baker Street", City: "London", Postcode: "NW1 6XE", State: "England",
+			CountryCode: "GB",
+		},
+		"55 Rue du Faubourg Saint-Honoré, 75008 Paris, France": {
+			HouseNumber: "55", Street: "Rue du Faubourg Saint-Honoré", City: "Paris", Postcode: "75008",
+			Country: "France", CountryCode: "FR",
+		},
+		"12 bis avenue des Champs-Élysées 75008 Paris": {
+			HouseNumber: "12 bis", Street: "avenue des Champs-Élysées", City: "Paris", Postcode: "75008",
+			CountryCode: "FR",
+		},
+		"Unter den Linden 77, 10117 Berlin, Deutschland": {
+			HouseNumber: "77", Street: "Unter den Linden", City: "Berlin", Postcode: "10117",
+			Country: "Deutschland", CountryCode: "DE",
+		},
+		"Friedrichstraße 43a, 10117 Berlin": {
+			HouseNumber: "43a", Street: "Friedrichstraße", City: "Berlin", Postcode: "10117", CountryCode: "DE",
+		},
+		"ул. Тверская, д. 7, г. Москва, 125009, Россия": {
+			HouseNumber: "7", Street: "ул. Тверская", City: "Москва", Postcode: "125009",
+			Country: "Россия", CountryCode: "RU",
+		},
+		"Россия, 190000, Санкт-Петербург, Невский проспект 28": {
+			HouseNumber: "28", Street: "Невский проспект", City: "Санкт-Петербург", Postcode: "190000",
+			Country: "Россия", CountryCode: "RU",
+		},
+	} {
+		p, err := address.Parse(s)
+		assert.NoError(t, err, s)
+		expected.FormattedAddress = s
+		assert.Equal(t, expected, p.Address, s)
+		for f := range p.Confidence {
+			assert.True(t, p.Confidence[f] > 0 && p.Confidence[f] <= 1, s)
+		}
+	}
+}
+
+func TestParseConfidence(t *testing.T) {
+	p, err := address.Parse("64 Elizabeth Street, Melbourne VIC 3000, Australia")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, p.Confidence[address.CountryCode])
+	assert.True(t, p.Confidence[address.Postcode] > p.Confidence[address.City])
+	_, ok := p.Confidence[address.Suburb]
+	assert.False(t, ok)
+
+	p, err = address.Parse("Somewhere, Nowhere")
+	assert.NoError(t, err)
+	assert.Equal(t, "", p.CountryCode)
+	assert.Equal(t, "Nowhere", p.City)
+	assert.True(t, p.Confidence[address.City] <= 0.5)
+
+	p, err = address.Parse("12")
+	assert.NoError(t, err)
+	assert.Equal(t, "", p.City)
+	_, ok = p.Confidence[address.City]
+	assert.False(t, ok)
+}
+
+func TestParseCountry(t *testing.T) {
+	p, err := address.ParseCountry("Main Street 5, 8001 Zürich", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "8001", p.Postcode)
+
+	p, err = address.ParseCountry("1 Main Street, Springfield", "us")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Address{
+		FormattedAddress: "1 Main Street, Springfield", HouseNumber: "1", Street: "Main Street", City: "Springfield",
+		CountryCode: "US",
+	}, p.Address)
+	assert.Equal(t, 1.0, p.Confidence[address.CountryCode])
+
+	_, err = address.ParseCountry("1 Main Street", "JP")
+	assert.Error(t, err)
+	_, err = address.Parse(" , ")
+	assert.Equal(t, address.ErrEmptyAddress, err)
+}
+
+func TestParseAmbiguousCountry(t *testing.T) {
+	p, err := address.Parse("1 Market St, Wilmington, DE")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Address{
+		FormattedAddress: "1 Market St, Wilmington, DE", HouseNumber: "1", Street: "Market St", City: "Wilmington",
+		State: "DE", CountryCode: "US",
+	}, p.Address)
+	assert.True(t, p.Confidence[address.CountryCode] < 1)
+
+	p, err = address.Parse("Unter den Linden 77, 10117 Berlin, DE")
+	assert.NoError(t, err)
+	assert.Equal(t, "DE", p.CountryCode)
+	assert.Equal(t, "", p.State)
+	assert.Equal(t, 0.5, p.Confidence[address.Country])
+
+	// a street type or a postcode alone tells no country
+	for _, s := range []string{"10 Downing Street", "1 Lambton Quay, Wellington 6011"} {
+		p, err = address.Parse(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, "", p.CountryCode, s)
+		_, ok := p.Confidence[address.CountryCode]
+		assert.False(t, ok, s)
+	}
+}
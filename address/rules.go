@@ -0,0 +1,142 @@
+package address
+
+import (
+	"regexp"
+	"strings"
+)
+
+// countries are the names and codes of the countries with rules, in lower case, by ISO 3166-1 alpha-2 code
+var countries = map[string]string{
+	"australia": "AU", "au": "AU", "aus": "AU",
+	"united states": "US", "united states of america": "US", "usa": "US", "us": "US", "u.s.a": "US", "u.s": "US",
+	"united kingdom": "GB", "uk": "GB", "gb": "GB", "great britain": "GB",
+	"england": "GB", "scotland": "GB", "wales": "GB", "northern ireland": "GB",
+	"france": "FR", "fr": "FR",
+	"germany": "DE", "deutschland": "DE", "de": "DE", "bundesrepublik deutschland": "DE",
+	"russia": "RU", "russian federation": "RU", "россия": "RU", "российская федерация": "RU", "рф": "RU", "ru": "RU",
+}
+
+// ukNations are the countries of the United Kingdom, which are its states in geo.Address
+var ukNations = map[string]bool{"england": true, "scotland": true, "wales": true, "northern ireland": true}
+
+func set(words ...string) map[string]bool {
+	s := make(map[string]bool, len(words))
+	for _, w := range words {
+		s[w] = true
+	}
+	return s
+}
+
+var englishStreetTypes = set(
+	"street", "st", "road", "rd", "avenue", "ave", "av", "boulevard", "blvd", "drive", "dr", "lane", "ln",
+	"court", "ct", "place", "pl", "parkway", "pkwy", "terrace", "tce", "way", "crescent", "cres", "highway", "hwy",
+	"square", "sq", "close", "parade", "pde", "circuit", "cct", "grove", "esplanade", "esp", "row", "mews",
+	"gardens", "walk", "hill", "circle", "cir", "trail", "plaza", "broadway", "alley", "arcade",
+)
+
+var countryRules = map[string]rules{
+	"AU": {
+		postcode: regexp.MustCompile(`\b\d{4}\b`),
+		states: set("nsw", "vic", "qld", "sa", "wa", "tas", "nt", "act",
+			"new south wales", "victoria", "queensland", "south australia", "western australia", "tasmania",
+			"northern territory", "australian capital territory"),
+		numberFirst: true,
+		streetTypes: englishStreetTypes,
+	},
+	"US": {
+		postcode: regexp.MustCompile(`\b\d{5}(?:-\d{4})?\b`),
+		states: set("al", "ak", "az", "ar", "ca", "co", "ct", "de", "fl", "ga", "hi", "id", "il", "in", "ia", "ks", "ky",
+			"la", "me", "md", "ma", "mi", "mn", "ms", "mo", "mt", "ne", "nv", "nh", "nj", "nm", "ny", "nc", "nd", "oh",
+			"ok", "or", "pa", "ri", "sc", "sd", "tn", "tx", "ut", "vt", "va", "wa", "wv", "wi", "wy", "dc", "pr",
+			"alabama", "alaska", "arizona", "arkansas", "california", "colorado", "connecticut", "delaware", "florida",
+			"georgia", "hawaii", "idaho", "illinois", "indiana", "iowa", "kansas", "kentucky", "louisiana", "maine",
+			"maryland", "massachusetts", "michigan", "minnesota", "mississippi", "missouri", "montana", "nebraska",
+			"nevada", "new hampshire", "new jersey", "new mexico", "new york", "north carolina", "north dakota", "ohio",
+			"oklahoma", "oregon", "pennsylvania", "rhode island", "south carolina", "south dakota", "tennessee", "texas",
+			"utah", "vermont", "virginia", "washington", "west virginia", "wisconsin", "wyoming",
+			"district of columbia", "puerto rico"),
+		numberFirst: true,
+		streetTypes: englishStreetTypes,
+	},
+	"GB": {
+		postcode:    regexp.MustCompile(`(?i)\b(?:GIR ?0AA|[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2})\b`),
+		states:      ukNations,
+		numberFirst: true,
+		streetTypes: englishStreetTypes,
+	},
+	"FR": {
+		postcode:      regexp.MustCompile(`\b\d{5}\b`),
+		postcodeFirst: true,
+		numberFirst:   true,
+		streetTypes: set("rue", "avenue", "av", "boulevard", "bd", "bld", "place", "pl", "chemin", "ch", "allée", "allee",
+			"impasse", "imp", "quai", "cours", "route", "rte", "square", "passage", "voie", "faubourg", "fg",
+			"esplanade", "promenade", "sentier", "rond-point", "cité", "hameau", "lieu-dit"),
+	},
+	"DE": {
+		postcode:      regexp.MustCompile(`\b\d{5}\b`),
+		postcodeFirst: true,
+		states: set("baden-württemberg", "bayern", "berlin", "brandenburg", "bremen", "hamburg", "hessen",
+			"mecklenburg-vorpommern", "niedersachsen", "nordrhein-westfalen", "rheinland-pfalz", "saarland", "sachsen",
+			"sachsen-anhalt", "schleswig-holstein", "thüringen"),
+		streetTypes: set("straße", "strasse", "str", "weg", "platz", "allee", "gasse", "ring", "damm", "ufer",
+			"chaussee", "steig", "pfad", "markt", "kai"),
+		streetEndings: []string{"straße", "strasse", "str.", "weg", "platz", "allee", "gasse", "ring", "damm", "ufer",
+			"chaussee", "steig", "pfad", "markt"},
+	},
+}
+
+// genericRules parse addresses of countries without rules of their own
+var genericRules = rules{
+	postcode:    regexp.MustCompile(`\b\d{4,6}\b`),
+	numberFirst: true,
+	streetTypes: englishStreetTypes,
+}
+
+// Russian addresses are marked by abbreviations such as "ул." and often written from the country to the house
+var (
+	russianPostcode = regexp.MustCompile(`^\d{6}$`)
+	russianHouse    = regexp.MustCompile(`(?i)^(?:(?:д|дом)\.?\s*)?(\d+[а-яА-Я]?(?:\s?[/-]\s?\d+)?(?:\s?(?:к|корп|стр|с)\.?\s?\d+)*)$`)
+	russianCity     = regexp.MustCompile(`^(?:г|гор|город)\.?\s+(.+)$|^(.+?)\s+(?:г|гор)\.?$`)
+	russianState    = regexp.MustCompile(`(?i)(?:^|\s)(?:обл|область|край|респ|республика|ао|автономный округ)\.?(?:\s|$)`)
+	russianSuburb   = regexp.MustCompile(`(?i)(?:^|\s)(?:р-н|район|мкр|микрорайон|пос|посёлок|поселок)\.?(?:\s|$)`)
+	russianStreet   = regexp.MustCompile(`(?i)(?:^|\s)(?:ул|улица|пр|пр-т|проспект|пер|переулок|ш|шоссе|наб|набережная|б-р|бульвар|пл|площадь|проезд|туп|тупик|аллея)\.?(?:\s|$)`)
+	russianCities   = set("москва", "санкт-петербург", "новосибирск", "екатеринбург", "казань", "нижний новгород",
+		"челябинск", "самара", "омск", "ростов-на-дону", "уфа", "красноярск", "воронеж", "пермь", "волгоград",
+		"moscow", "saint petersburg", "st petersburg")
+)
+
+// parseRussian sets the fields of p found in parts by their markers, in whichever order they come
+func parseRussian(parts []string, p *Parsed) {
+	var unmarked []string
+	for _, part := range parts {
+		lower := strings.ToLower(part)
+		switch {
+		case russianPostcode.MatchString(part):
+			p.set(Postcode, part, 0.95)
+		case russianStreet.MatchString(lower):
+			// the house may follow the street in the same part
+			if m := numberLastPattern.FindStringSubmatch(part); m != nil && !russianStreet.MatchString(" "+strings.ToLower(m[2])) {
+				p.set(HouseNumber, m[2], 0.85)
+				part = m[1]
+			}
+			p.set(Street, part, 0.9)
+		case russianHouse.MatchString(lower):
+			p.set(HouseNumber, russianHouse.FindStringSubmatch(part)[1], 0.9)
+		case russianCity.MatchString(lower):
+			m := russianCity.FindStringSubmatch(part)
+			p.set(City, m[1]+m[2], 0.9)
+		case russianState.MatchString(lower):
+			p.set(State, part, 0.9)
+		case russianSuburb.MatchString(lower):
+			p.set(Suburb, part, 0.7)
+		case russianCities[lower]:
+			p.set(City, part, 0.85)
+		default:
+			unmarked = append(unmarked, part)
+		}
+	}
+	// a part without a marker is most likely the city
+	if len(unmarked) > 0 {
+		p.set(City, unmarked[0], 0.5)
+	}
+}
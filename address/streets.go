@@ -0,0 +1,115 @@
+package address
+
+import "strings"
+
+// streetAbbreviations are the usual abbreviations of street types by language, keyed by the lower case full word
+var streetAbbreviations = map[string]map[string]string{
+	"en": {
+		"street": "St", "road": "Rd", "avenue": "Ave", "boulevard": "Blvd", "drive": "Dr", "lane": "Ln",
+		"court": "Ct", "place": "Pl", "parkway": "Pkwy", "terrace": "Tce", "crescent": "Cres", "highway": "Hwy",
+		"square": "Sq", "parade": "Pde", "circuit": "Cct", "esplanade": "Esp", "circle": "Cir", "trail": "Trl",
+	},
+	"fr": {
+		"avenue": "Av.", "boulevard": "Bd", "place": "Pl.", "chemin": "Ch.", "impasse": "Imp.", "route": "Rte",
+		"faubourg": "Fg", "allée": "All.", "square": "Sq.",
+	},
+	"de": {"straße": "Str.", "strasse": "Str.", "platz": "Pl."},
+	"ru": {
+		"улица": "ул.", "проспект": "пр-т", "переулок": "пер.", "шоссе": "ш.", "набережная": "наб.",
+		"площадь": "пл.", "бульвар": "б-р", "проезд": "пр-д",
+	},
+}
+
+// typeFirst and typeLast tell by language whether the street type starts or ends the name of a street,
+// as in "Rue de la Paix" and "Elizabeth Street"
+var (
+	typeFirst = map[string]bool{"fr": true, "ru": true}
+	typeLast  = map[string]bool{"en": true, "de": true, "ru": true}
+)
+
+// compassAbbreviations abbreviate the compass words before or after an English street type,
+// as in "North Main Street" and "Main Street West"
+var compassAbbreviations = map[string]string{"north": "N", "south": "S", "east": "E", "west": "W"}
+
+// saints are abbreviated in the names of French streets, as in "Rue Saint Denis"
+var saints = map[string]string{"saint": "St", "sainte": "Ste"}
+
+// countryLanguages are the languages of the street types of countries, English elsewhere
+var countryLanguages = map[string]string{
+	"FR": "fr", "BE": "fr", "LU": "fr", "MC": "fr",
+	"DE": "de", "AT": "de", "CH": "de", "LI": "de",
+	"RU": "ru", "BY": "ru", "KZ": "ru",
+}
+
+func language(countryCode string) string {
+	if l, ok := countryLanguages[strings.ToUpper(countryCode)]; ok {
+		return l
+	}
+	return "en"
+}
+
+// abbreviateStreet returns street with its street type abbreviated in the language of countryCode,
+// where the language puts it, and the compass words or saints around it
+func abbreviateStreet(street, countryCode string) string {
+	lang := language(countryCode)
+	abbreviations := streetAbbreviations[lang]
+	words := strings.Fields(street)
+	if len(words) == 0 {
+		return street
+	}
+	abbreviate := func(i int) bool {
+		if a, ok := abbreviations[strings.ToLower(words[i])]; ok {
+			words[i] = a
+			return true
+		}
+		return false
+	}
+
+	// German streets are mostly written as one word, as in "Friedrichstraße"
+	if lang == "de" {
+		last := len(words) - 1
+		if !abbreviate(last) {
+			lower := strings.ToLower(words[last])
+			for full, a := range abbreviations {
+				if strings.HasSuffix(lower, full) && len(lower) > len(full) {
+					words[last] = words[last][:len(words[last])-len(full)] + strings.ToLower(a)
+					break
+				}
+			}
+		}
+		return strings.Join(words, " ")
+	}
+
+	// a street named after its type alone is left as it is
+	if len(words) < 2 {
+		return street
+	}
+	if lang == "fr" {
+		for i := 1; i < len(words)-1; i++ {
+			if a, ok := saints[strings.ToLower(words[i])]; ok {
+				words[i] = a
+			}
+		}
+	}
+	if typeFirst[lang] && abbreviate(0) {
+		return strings.Join(words, " ")
+	}
+	if !typeLast[lang] {
+		return strings.Join(words, " ")
+	}
+	// the type is the last word, or the one before a compass word
+	last := len(words) - 1
+	suffix := lang == "en" && last > 1 && compassAbbreviations[strings.ToLower(words[last])] != ""
+	if suffix {
+		last--
+	}
+	if !abbreviate(last) {
+		return strings.Join(words, " ")
+	}
+	if suffix {
+		words[last+1] = compassAbbreviations[strings.ToLower(words[last+1])]
+	} else if lang == "en" && last > 1 && compassAbbreviations[strings.ToLower(words[0])] != "" {
+		words[0] = compassAbbreviations[strings.ToLower(words[0])]
+	}
+	return strings.Join(words, " ")
+}
@@ -11,13 +11,7 @@ import (
 type (
 	baseURL         string
 	geocodeResponse struct {
-		Candidates []struct {
-			Address  string
-			Location struct {
-				X float64
-				Y float64
-			}
-		}
+		Candidates []candidate
 
 		ReverseAddress struct {
 			MatchAddr    string `json:"Match_addr"`
@@ -32,9 +26,37 @@ type (
 			Postal       string
 			CountryCode  string
 		} `json:"address"`
+
+		Error *serviceError
+	}
+	serviceError struct {
+		Code    int
+		Message string
+		Details []string
+	}
+	candidate struct {
+		Address  string
+		Location struct {
+			X float64
+			Y float64
+		}
+		Score      float64
+		Attributes map[string]interface{}
+		Extent     *struct {
+			XMin, YMin, XMax, YMax float64
+		}
 	}
 )
 
+func init() {
+	geo.Register("arcgis", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "langCode", "sourceCountry"), nil
+	})
+}
+
 // Geocoder constructs ArcGIS geocoder
 func Geocoder(token string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -55,6 +77,25 @@ func getUrl(token string, baseURLs ...string) string {
 	return url
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "arcgis",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: "Esri",
+		Licence:     "Esri Master License Agreement",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	params := fmt.Sprintf("findAddressCandidates?f=json&maxLocations=%d&SingleLine=%s", 1, address)
 	return strings.Replace(string(b), "*", params, 1)
@@ -66,6 +107,9 @@ func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
 }
 
 func (r *geocodeResponse) Location() (*geo.Location, error) {
+	if err := r.err(); err != nil {
+		return nil, err
+	}
 	if len(r.Candidates) == 0 {
 		return nil, nil
 	}
@@ -77,7 +121,18 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.Candidates) == 0 || r.Candidates[0].Extent == nil {
+		return nil
+	}
+	e := r.Candidates[0].Extent
+	return &geo.BoundingBox{South: e.YMin, West: e.XMin, North: e.YMax, East: e.XMax}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
+	if err := r.err(); err != nil {
+		return nil, err
+	}
 	addr := &geo.Address{
 		FormattedAddress: r.ReverseAddress.MatchAddr,
 		Street:           r.ReverseAddress.Address,
@@ -89,3 +144,14 @@ func (r *geocodeResponse) Address() (*geo.Address, error) {
 
 	return addr, nil
 }
+
+func (r *geocodeResponse) err() error {
+	if r.Error == nil {
+		return nil
+	}
+	return r.Error
+}
+
+func (e *serviceError) Error() string {
+	return strings.TrimSpace(fmt.Sprintf("arcgis error %d: %s %s", e.Code, e.Message, strings.Join(e.Details, " ")))
+}
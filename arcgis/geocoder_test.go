@@ -39,7 +39,7 @@ func TestReverseGeocode(t *testing.T) {
 	ts := testServer(reverseResp)
 	defer ts.Close()
 
-	code := "USA"
+	code := "US"
 	state := "California"
 	lat := 34.056488119308924
 	lng := -117.1956703176181
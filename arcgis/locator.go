@@ -0,0 +1,264 @@
+package arcgis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	geo "github.com/codingsince1985/geo-golang"
+)
+
+// DefaultLocatorURL is the World geocoding service of ArcGIS Online
+const DefaultLocatorURL = "https://geocode.arcgis.com/arcgis/rest/services/World/GeocodeServer"
+
+// maxBatchSize is the default maximum number of records accepted by geocodeAddresses
+const maxBatchSize = 1000
+
+// wgs84 is the spatial reference of the locations requested from a locator
+const wgs84 = "4326"
+
+type (
+	locatorEndpoint struct {
+		url    string
+		tokens geo.TokenSource
+	}
+
+	batchResponse struct {
+		Locations []struct {
+			Address  string
+			Location struct {
+				X, Y interface{} // "NaN" when not matched
+			}
+			Score      float64
+			Attributes map[string]interface{}
+		}
+		Error *serviceError
+	}
+)
+
+// Locator is a geo.BatchGeocoder for a GeocodeServer, such as the World geocoding service or a locator of ArcGIS Enterprise
+type Locator struct {
+	geo.HTTPGeocoder
+	url locatorEndpoint
+}
+
+// Query is a structured address for a locator, or a whole address in SingleLine
+type Query struct {
+	SingleLine   string
+	Address      string
+	Address2     string
+	Neighborhood string
+	City         string
+	Subregion    string
+	Region       string
+	Postal       string
+	CountryCode  string
+}
+
+// Candidate is a match of a locator, with its score and the attributes requested by outFields
+type Candidate struct {
+	Address    string
+	Location   geo.Location
+	Score      float64
+	Attributes map[string]interface{}
+}
+
+// GeocoderWithLocator constructs ArcGIS geocoder for the GeocodeServer at locatorURL, such as DefaultLocatorURL,
+// sending a token from tokens with each request unless it is nil
+func GeocoderWithLocator(locatorURL string, tokens geo.TokenSource) Locator {
+	u := locatorEndpoint{url: strings.TrimSuffix(locatorURL, "/"), tokens: tokens}
+	return Locator{
+		HTTPGeocoder: geo.HTTPGeocoder{
+			EndpointBuilder:       u,
+			ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
+		},
+		url: u,
+	}
+}
+
+// Capabilities returns those of the geocoding service, with candidates and batch geocoding
+func (u locatorEndpoint) Capabilities() geo.Capabilities {
+	c := capabilities
+	c.MultipleResults = true
+	c.MaxBatchSize = maxBatchSize
+	return c
+}
+
+func (u locatorEndpoint) GeocodeURL(address string) string {
+	return u.url + "/findAddressCandidates?f=json&outSR=" + wgs84 + "&maxLocations=1&SingleLine=" + address
+}
+
+func (u locatorEndpoint) ReverseGeocodeURL(l geo.Location) string {
+	return u.url + fmt.Sprintf("/reverseGeocode?f=json&outSR=%s&location=%f,%f", wgs84, l.Lng, l.Lat)
+}
+
+// Authorize adds a token, requested with the client of the locator, to the query of req
+func (u locatorEndpoint) Authorize(req *http.Request) error {
+	if u.tokens == nil {
+		return nil
+	}
+	token, err := u.tokens.Token(geo.RequestClient(req))
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Set("token", token)
+	req.URL.RawQuery = q.Encode()
+	return nil
+}
+
+func (q Query) attributes() map[string]string {
+	attributes := map[string]string{}
+	for name, value := range map[string]string{
+		"SingleLine":   q.SingleLine,
+		"Address":      q.Address,
+		"Address2":     q.Address2,
+		"Neighborhood": q.Neighborhood,
+		"City":         q.City,
+		"Subregion":    q.Subregion,
+		"Region":       q.Region,
+		"Postal":       q.Postal,
+		"CountryCode":  q.CountryCode,
+	} {
+		if value != "" {
+			attributes[name] = value
+		}
+	}
+	return attributes
+}
+
+// FindAddressCandidates returns up to max candidates for q, best first, with the attributes in outFields, or all of them for "*"
+func (l Locator) FindAddressCandidates(q Query, outFields []string, max int) ([]Candidate, error) {
+	params := url.Values{"f": {"json"}, "outSR": {wgs84}}
+	if max > 0 {
+		params.Set("maxLocations", strconv.Itoa(max))
+	}
+	if len(outFields) > 0 {
+		params.Set("outFields", strings.Join(outFields, ","))
+	}
+	for name, value := range q.attributes() {
+		params.Set(name, value)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, l.url.url+"/findAddressCandidates?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var r geocodeResponse
+	if err := l.Do(req, &r); err != nil {
+		return nil, err
+	}
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, len(r.Candidates))
+	for i, c := range r.Candidates {
+		candidates[i] = Candidate{
+			Address:    c.Address,
+			Location:   geo.Location{Lat: c.Location.Y, Lng: c.Location.X},
+			Score:      c.Score,
+			Attributes: c.Attributes,
+		}
+	}
+	return candidates, nil
+}
+
+// GeocodeAddresses geocodes queries in one request to geocodeAddresses, with the attributes in outFields,
+// returning nil for those not matched
+func (l Locator) GeocodeAddresses(queries []Query, outFields []string) ([]*Candidate, error) {
+	if len(queries) > maxBatchSize {
+		return nil, fmt.Errorf("batch of %d queries exceeds %d", len(queries), maxBatchSize)
+	}
+
+	type record struct {
+		Attributes map[string]interface{} `json:"attributes"`
+	}
+	records := make([]record, len(queries))
+	for i, q := range queries {
+		attributes := map[string]interface{}{"OBJECTID": i + 1}
+		for name, value := range q.attributes() {
+			attributes[name] = value
+		}
+		records[i] = record{attributes}
+	}
+	addresses, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"f": {"json"}, "outSR": {wgs84}, "addresses": {string(addresses)}}
+	if len(outFields) > 0 {
+		params.Set("outFields", strings.Join(outFields, ","))
+	}
+	req, err := http.NewRequest(http.MethodPost, l.url.url+"/geocodeAddresses", strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var r batchResponse
+	if err := l.Do(req, &r); err != nil {
+		return nil, err
+	}
+	if r.Error != nil {
+		return nil, r.Error
+	}
+
+	candidates := make([]*Candidate, len(queries))
+	for _, loc := range r.Locations {
+		id, _ := loc.Attributes["ResultID"].(float64)
+		x, okX := loc.Location.X.(float64)
+		y, okY := loc.Location.Y.(float64)
+		i := int(id) - 1
+		if i < 0 || i >= len(queries) || !okX || !okY || loc.Attributes["Status"] == "U" {
+			continue
+		}
+		candidates[i] = &Candidate{
+			Address:    loc.Address,
+			Location:   geo.Location{Lat: y, Lng: x},
+			Score:      loc.Score,
+			Attributes: loc.Attributes,
+		}
+	}
+	return candidates, nil
+}
+
+// MaxBatchSize returns the maximum number of queries in a batch request
+func (l Locator) MaxBatchSize() int { return maxBatchSize }
+
+// BatchGeocode returns locations for addresses
+func (l Locator) BatchGeocode(addresses []string) ([]*geo.Location, error) {
+	queries := make([]Query, len(addresses))
+	for i, address := range addresses {
+		queries[i] = Query{SingleLine: address}
+	}
+	candidates, err := l.GeocodeAddresses(queries, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]*geo.Location, len(candidates))
+	for i, c := range candidates {
+		if c != nil {
+			locations[i] = &c.Location
+		}
+	}
+	return locations, nil
+}
+
+// BatchReverseGeocode returns addresses for locations, one request at a time as locators have no batch endpoint for it
+func (l Locator) BatchReverseGeocode(locations []geo.Location) ([]*geo.Address, error) {
+	addresses := make([]*geo.Address, len(locations))
+	for i, loc := range locations {
+		addr, err := l.ReverseGeocode(loc.Lat, loc.Lng)
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = addr
+	}
+	return addresses, nil
+}
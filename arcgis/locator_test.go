@@ -0,0 +1,210 @@
+package arcgis
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOAuthTokenSourceRefreshes(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		req.ParseForm()
+		if req.Form.Get("grant_type") != "client_credentials" || req.Form.Get("client_id") != "id" {
+			t.Fatalf("Got: %v\tExpected: client credentials\n", req.Form)
+		}
+		// expires within expiryMargin, so it is refreshed every time
+		resp.Write([]byte(`{"access_token": "token", "expires_in": 30}`))
+	}))
+	defer ts.Close()
+
+	tokens := OAuthTokenSource(ts.URL, "id", "secret")
+	for i := 0; i < 2; i++ {
+		if token, err := tokens.Token(nil); err != nil || token != "token" {
+			t.Fatalf("Got: %v %v\tExpected: token\n", token, err)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("Got: %d requests\tExpected: 2\n", requests)
+	}
+}
+
+func TestGenerateTokenSourceCaches(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		resp.Write([]byte(`{"token": "token", "expires": 32503680000000, "ssl": true}`))
+	}))
+	defer ts.Close()
+
+	tokens := GenerateTokenSource(ts.URL, "user", "password")
+	for i := 0; i < 2; i++ {
+		if token, err := tokens.Token(nil); err != nil || token != "token" {
+			t.Fatalf("Got: %v %v\tExpected: token\n", token, err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("Got: %d requests\tExpected: 1\n", requests)
+	}
+}
+
+func TestTokenError(t *testing.T) {
+	ts := testServer(`{"error": {"code": 400, "message": "Unable to generate token.", "details": ["Invalid username or password."]}}`)
+	defer ts.Close()
+
+	if _, err := GenerateTokenSource(ts.URL, "user", "wrong").Token(nil); err == nil || !strings.Contains(err.Error(), "Invalid username") {
+		t.Fatalf("Got: %v\tExpected: token error\n", err)
+	}
+}
+
+func TestLocatorGeocodeWithToken(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		query = req.URL.Path + "?" + req.URL.RawQuery
+		resp.Write([]byte(geocodeResp))
+	}))
+	defer ts.Close()
+
+	loc, err := GeocoderWithLocator(ts.URL+"/GeocodeServer/", StaticToken("secret")).Geocode("380 New York, Redlands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(query, "/GeocodeServer/findAddressCandidates?") || !strings.Contains(query, "token=secret") {
+		t.Fatalf("Got: %v\tExpected: findAddressCandidates with token\n", query)
+	}
+	if math.Abs(loc.Lat-34.056488119308924) > eps {
+		t.Fatalf("Got: %v\tExpected: 34.056488119308924\n", loc.Lat)
+	}
+}
+
+type countingTransport struct{ paths []string }
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.paths = append(c.paths, req.URL.Path)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestLocatorTokenWithClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			resp.Write([]byte(`{"access_token": "token", "expires_in": 7200}`))
+			return
+		}
+		resp.Write([]byte(geocodeResp))
+	}))
+	defer ts.Close()
+
+	transport := &countingTransport{}
+	locator := GeocoderWithLocator(ts.URL+"/GeocodeServer", OAuthTokenSource(ts.URL+"/token", "id", "secret"))
+	locator.Client = &http.Client{Transport: transport}
+	if _, err := locator.Geocode("380 New York, Redlands"); err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.paths) != 2 || transport.paths[0] != "/token" {
+		t.Fatalf("Got: %v\tExpected: token and geocode requests with the client of the locator\n", transport.paths)
+	}
+}
+
+func TestLocatorInvalidToken(t *testing.T) {
+	ts := testServer(`{"error": {"code": 498, "message": "Invalid Token", "details": []}}`)
+	defer ts.Close()
+
+	if _, err := GeocoderWithLocator(ts.URL, StaticToken("expired")).Geocode("380 New York, Redlands"); err == nil {
+		t.Fatal("Got: nil\tExpected: invalid token error")
+	}
+}
+
+func TestFindAddressCandidates(t *testing.T) {
+	var params map[string][]string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		params = req.URL.Query()
+		resp.Write([]byte(candidatesResp))
+	}))
+	defer ts.Close()
+
+	candidates, err := GeocoderWithLocator(ts.URL, nil).FindAddressCandidates(
+		Query{Address: "380 New York St", City: "Redlands", Region: "CA"}, []string{"Addr_type", "Postal"}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{
+		"Address": "380 New York St", "City": "Redlands", "Region": "CA", "outFields": "Addr_type,Postal", "maxLocations": "5", "outSR": "4326",
+	} {
+		if len(params[name]) != 1 || params[name][0] != value {
+			t.Fatalf("Got: %s=%v\tExpected: %s\n", name, params[name], value)
+		}
+	}
+	if _, ok := params["SingleLine"]; ok {
+		t.Fatal("Got: SingleLine\tExpected: structured address only")
+	}
+	if len(candidates) != 2 || candidates[0].Score != 100 || candidates[0].Attributes["Addr_type"] != "PointAddress" {
+		t.Fatalf("Got: %+v\tExpected: 2 candidates\n", candidates)
+	}
+}
+
+func TestGeocodeAddresses(t *testing.T) {
+	var records struct {
+		Records []struct {
+			Attributes map[string]interface{}
+		}
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		json.Unmarshal([]byte(req.PostForm.Get("addresses")), &records)
+		resp.Write([]byte(batchResp))
+	}))
+	defer ts.Close()
+
+	locs, err := GeocoderWithLocator(ts.URL, nil).BatchGeocode([]string{"nowhere", "380 New York St, Redlands, CA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records.Records) != 2 || records.Records[1].Attributes["SingleLine"] != "380 New York St, Redlands, CA" {
+		t.Fatalf("Got: %+v\tExpected: 2 records\n", records)
+	}
+	if len(locs) != 2 || locs[0] != nil || locs[1] == nil || math.Abs(locs[1].Lng+117.19567031761801) > eps {
+		t.Fatalf("Got: %v\tExpected: nil and a location\n", locs)
+	}
+}
+
+const (
+	candidatesResp = `{
+ "spatialReference": {"wkid": 4326, "latestWkid": 4326},
+ "candidates": [
+  {
+   "address": "380 New York St, Redlands, California, 92373",
+   "location": {"x": -117.19567031761801, "y": 34.056488119308924},
+   "score": 100,
+   "attributes": {"Addr_type": "PointAddress", "Postal": "92373"}
+  },
+  {
+   "address": "New York St, Redlands, California, 92373",
+   "location": {"x": -117.19580, "y": 34.05744},
+   "score": 87.5,
+   "attributes": {"Addr_type": "StreetName", "Postal": "92373"}
+  }
+ ]
+}`
+
+	batchResp = `{
+ "spatialReference": {"wkid": 4326, "latestWkid": 4326},
+ "locations": [
+  {
+   "address": "380 New York St, Redlands, California, 92373",
+   "location": {"x": -117.19567031761801, "y": 34.056488119308924},
+   "score": 100,
+   "attributes": {"ResultID": 2, "Status": "M"}
+  },
+  {
+   "address": "",
+   "location": {"x": "NaN", "y": "NaN"},
+   "score": 0,
+   "attributes": {"ResultID": 1, "Status": "U"}
+  }
+ ]
+}`
+)
@@ -0,0 +1,73 @@
+package arcgis
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	geo "github.com/codingsince1985/geo-golang"
+)
+
+// DefaultTokenURL is the OAuth 2.0 token endpoint of ArcGIS Online
+const DefaultTokenURL = "https://www.arcgis.com/sharing/rest/oauth2/token"
+
+// tokenExpiration is the lifetime in minutes requested for new tokens
+const tokenExpiration = 60
+
+type staticToken string
+
+// StaticToken returns a TokenSource of a token that never changes, such as an API key
+func StaticToken(token string) geo.TokenSource { return staticToken(token) }
+
+func (t staticToken) Token(*http.Client) (string, error) { return string(t), nil }
+
+// OAuthTokenSource returns a TokenSource of OAuth 2.0 application tokens acquired with client credentials
+// from the token endpoint of a portal, such as DefaultTokenURL, and refreshed before they expire
+func OAuthTokenSource(tokenURL, clientID, clientSecret string) geo.TokenSource {
+	return geo.RefreshingTokenSource(func(client *http.Client) (string, time.Time, error) {
+		var r struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+			Error       *serviceError
+		}
+		err := geo.PostTokenForm(client, tokenURL, url.Values{
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"grant_type":    {"client_credentials"},
+			"expiration":    {strconv.Itoa(tokenExpiration)},
+			"f":             {"json"},
+		}, nil, &r)
+		if err == nil && r.Error != nil {
+			err = r.Error
+		}
+		return r.AccessToken, geo.TokenExpiry(r.ExpiresIn), err
+	})
+}
+
+// GenerateTokenSource returns a TokenSource of tokens acquired for a named user from the generateToken endpoint
+// of ArcGIS Enterprise, such as https://host/portal/sharing/rest/generateToken, and refreshed before they expire
+func GenerateTokenSource(tokenURL, username, password string) geo.TokenSource {
+	return geo.RefreshingTokenSource(func(client *http.Client) (string, time.Time, error) {
+		var r struct {
+			Token   string
+			Expires int64 // milliseconds since epoch
+			Error   *serviceError
+		}
+		err := geo.PostTokenForm(client, tokenURL, url.Values{
+			"username":   {username},
+			"password":   {password},
+			"client":     {"requestip"},
+			"expiration": {strconv.Itoa(tokenExpiration)},
+			"f":          {"json"},
+		}, nil, &r)
+		if err == nil && r.Error != nil {
+			err = r.Error
+		}
+		var expiry time.Time
+		if r.Expires > 0 {
+			expiry = time.Unix(r.Expires/1000, r.Expires%1000*int64(time.Millisecond))
+		}
+		return r.Token, expiry, err
+	})
+}
@@ -0,0 +1,390 @@
+// Package batch looks up streams of addresses or locations through any geo.Geocoder with bounded concurrency,
+// using the native batch endpoint of geo.BatchGeocoder services when they have one
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// DefaultWorkers is the number of concurrent lookups when Options.Workers is zero
+const DefaultWorkers = 4
+
+// checkpointInterval is the minimum time between two saves of a Checkpoint
+const checkpointInterval = time.Second
+
+// Query is an address to geocode, or a location to reverse geocode when Address is empty
+type Query struct {
+	Address  string
+	Location geo.Location
+}
+
+// Result is the outcome of the Query at Index in the input, with Err set if its lookup failed.
+// Result is what the geocoder tells about Location, such as its bounding box and source, when an address is found
+type Result struct {
+	Index    int
+	Query    Query
+	Location *geo.Location
+	Result   *geo.Result
+	Address  *geo.Address
+	Err      error
+}
+
+// Iterator yields queries until it returns io.EOF
+type Iterator interface {
+	Next() (Query, error)
+}
+
+// contextIterator is implemented by Iterators whose Next may block, so that reading stops with the run
+type contextIterator interface {
+	nextContext(ctx context.Context) (Query, error)
+}
+
+// Progress counts the results handled so far, and how many of them failed
+type Progress struct {
+	Processed, Failed int
+}
+
+// Checkpoint persists how many leading queries have been handled, so an interrupted run can resume after them
+type Checkpoint interface {
+	Load() (int, error)
+	Save(int) error
+}
+
+// Options control how queries are looked up and results delivered
+type Options struct {
+	// Workers is the number of concurrent lookups, DefaultWorkers if zero
+	Workers int
+	// Ordered delivers results in the order of their queries, otherwise as soon as they are ready
+	Ordered bool
+	// BatchSize caps the number of queries sent in one request to a geo.BatchGeocoder, its MaxBatchSize if zero
+	BatchSize int
+	// Progress is called after each result is handled
+	Progress func(Progress)
+	// Checkpoint skips the queries handled by a previous run, and records the progress of this one.
+	// Unordered results handled beyond the checkpoint may be delivered again when resuming
+	Checkpoint Checkpoint
+}
+
+// Run looks up every query from it with g and passes each Result to handle, from a single goroutine.
+// It stops when it is exhausted, ctx is done or handle returns an error
+func Run(ctx context.Context, g geo.Geocoder, it Iterator, handle func(Result) error, opts Options) error {
+	start, err := resume(it, opts.Checkpoint)
+	if err != nil || start < 0 {
+		return err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	size := 1
+	if b, ok := g.(geo.BatchGeocoder); ok {
+		size = b.MaxBatchSize()
+		if opts.BatchSize > 0 && opts.BatchSize < size {
+			size = opts.BatchSize
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan []Result)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		readErr <- read(runCtx, it, start, size, jobs)
+	}()
+
+	results := make(chan Result)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var job []Result
+				select {
+				case j, ok := <-jobs:
+					if !ok {
+						return
+					}
+					job = j
+				case <-runCtx.Done():
+					return
+				}
+				lookup(g, job)
+				for _, r := range job {
+					select {
+					case results <- r:
+					case <-runCtx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	err = collect(results, start, handle, opts)
+	cancel()
+	for range results {
+		// wait for the workers to stop
+	}
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return <-readErr
+}
+
+// resume skips the queries handled according to c, returning the index of the first one left, or -1 if none is
+func resume(it Iterator, c Checkpoint) (int, error) {
+	if c == nil {
+		return 0, nil
+	}
+	n, err := c.Load()
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < n; i++ {
+		if _, err := it.Next(); err == io.EOF {
+			return -1, nil
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// read groups queries from it into jobs of up to size queries, numbering them from index
+func read(ctx context.Context, it Iterator, index, size int, jobs chan<- []Result) error {
+	job := make([]Result, 0, size)
+	send := func() bool {
+		select {
+		case jobs <- job:
+			job = make([]Result, 0, size)
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	next := it.Next
+	if c, ok := it.(contextIterator); ok {
+		next = func() (Query, error) { return c.nextContext(ctx) }
+	}
+
+	for {
+		q, err := next()
+		if err == io.EOF {
+			break
+		} else if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		job = append(job, Result{Index: index, Query: q})
+		index++
+		if len(job) == size && !send() {
+			return nil
+		}
+	}
+	if len(job) > 0 {
+		send()
+	}
+	return nil
+}
+
+func lookup(g geo.Geocoder, job []Result) {
+	if b, ok := g.(geo.BatchGeocoder); ok && len(job) > 1 {
+		lookupBatch(b, job)
+		return
+	}
+	for i := range job {
+		r := &job[i]
+		if r.Query.Address != "" {
+			if r.Result, r.Err = geo.GeocodeResult(g, r.Query.Address); r.Result != nil {
+				r.Location = &r.Result.Location
+			}
+		} else {
+			r.Address, r.Err = g.ReverseGeocode(r.Query.Location.Lat, r.Query.Location.Lng)
+		}
+	}
+}
+
+func lookupBatch(g geo.BatchGeocoder, job []Result) {
+	var (
+		addresses          []string
+		locations          []geo.Location
+		forwards, reverses []int
+	)
+	for i, r := range job {
+		if r.Query.Address != "" {
+			addresses = append(addresses, r.Query.Address)
+			forwards = append(forwards, i)
+		} else {
+			locations = append(locations, r.Query.Location)
+			reverses = append(reverses, i)
+		}
+	}
+
+	if len(addresses) > 0 {
+		locs, err := g.BatchGeocode(addresses)
+		for j, i := range forwards {
+			if err != nil {
+				job[i].Err = err
+			} else if j < len(locs) && locs[j] != nil {
+				job[i].Location = locs[j]
+				job[i].Result = &geo.Result{Location: *locs[j]}
+			}
+		}
+	}
+	if len(locations) > 0 {
+		addrs, err := g.BatchReverseGeocode(locations)
+		for j, i := range reverses {
+			if err != nil {
+				job[i].Err = err
+			} else if j < len(addrs) {
+				job[i].Address = addrs[j]
+			}
+		}
+	}
+}
+
+// collect hands results to handle, keeping track of next, the index of the first query not handled yet
+func collect(results <-chan Result, next int, handle func(Result) error, opts Options) error {
+	var (
+		p        Progress
+		buffered = map[int]Result{}
+		handled  = map[int]bool{}
+		saved    = next
+		lastSave = time.Now()
+	)
+	deliver := func(r Result) error {
+		if err := handle(r); err != nil {
+			return err
+		}
+		p.Processed++
+		if r.Err != nil {
+			p.Failed++
+		}
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+		return nil
+	}
+	checkpoint := func(force bool) error {
+		if opts.Checkpoint == nil || next == saved || (!force && time.Since(lastSave) < checkpointInterval) {
+			return nil
+		}
+		saved, lastSave = next, time.Now()
+		return opts.Checkpoint.Save(next)
+	}
+	// fail saves the progress made before handle failed with err
+	fail := func(err error) error {
+		if cerr := checkpoint(true); cerr != nil {
+			return fmt.Errorf("%v, and saving the checkpoint failed: %v", err, cerr)
+		}
+		return err
+	}
+
+	for r := range results {
+		if opts.Ordered {
+			buffered[r.Index] = r
+			for r, ok := buffered[next]; ok; r, ok = buffered[next] {
+				if err := deliver(r); err != nil {
+					return fail(err)
+				}
+				delete(buffered, next)
+				next++
+			}
+		} else {
+			if err := deliver(r); err != nil {
+				return fail(err)
+			}
+			handled[r.Index] = true
+			for handled[next] {
+				delete(handled, next)
+				next++
+			}
+		}
+		if err := checkpoint(false); err != nil {
+			return err
+		}
+	}
+	return checkpoint(true)
+}
+
+type sliceIterator []Query
+
+// Slice iterates over queries
+func Slice(queries []Query) Iterator {
+	it := sliceIterator(queries)
+	return &it
+}
+
+func (s *sliceIterator) Next() (Query, error) {
+	if len(*s) == 0 {
+		return Query{}, io.EOF
+	}
+	q := (*s)[0]
+	*s = (*s)[1:]
+	return q, nil
+}
+
+type chanIterator <-chan Query
+
+// Chan iterates over queries received from ch until it is closed
+func Chan(ch <-chan Query) Iterator { return chanIterator(ch) }
+
+func (c chanIterator) Next() (Query, error) { return c.nextContext(context.Background()) }
+
+func (c chanIterator) nextContext(ctx context.Context) (Query, error) {
+	select {
+	case q, ok := <-c:
+		if ok {
+			return q, nil
+		}
+		return Query{}, io.EOF
+	case <-ctx.Done():
+		return Query{}, ctx.Err()
+	}
+}
+
+type fileCheckpoint string
+
+// FileCheckpoint keeps the checkpoint in the file at path
+func FileCheckpoint(path string) Checkpoint { return fileCheckpoint(path) }
+
+func (f fileCheckpoint) Load() (int, error) {
+	data, err := ioutil.ReadFile(string(f))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func (f fileCheckpoint) Save(n int) error {
+	tmp := string(f) + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.Itoa(n)), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, string(f))
+}
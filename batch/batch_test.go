@@ -0,0 +1,238 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/batch"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	melbourne = geo.Location{Lat: -37.814107, Lng: 144.96328}
+	sydney    = geo.Location{Lat: -33.868820, Lng: 151.209296}
+	geocoder  = data.Geocoder(
+		data.AddressToLocation{
+			geo.Address{FormattedAddress: "Melbourne"}: melbourne,
+			geo.Address{FormattedAddress: "Sydney"}:    sydney,
+		},
+		data.LocationToAddress{
+			melbourne: geo.Address{FormattedAddress: "Melbourne"},
+		},
+	)
+)
+
+func queries(n int) []batch.Query {
+	qs := make([]batch.Query, n)
+	for i := range qs {
+		switch i % 3 {
+		case 0:
+			qs[i] = batch.Query{Address: "Melbourne"}
+		case 1:
+			qs[i] = batch.Query{Address: "Sydney"}
+		case 2:
+			qs[i] = batch.Query{Location: melbourne}
+		}
+	}
+	return qs
+}
+
+func TestRunOrdered(t *testing.T) {
+	var results []batch.Result
+	var progress []batch.Progress
+	err := batch.Run(context.Background(), geocoder, batch.Slice(queries(30)), func(r batch.Result) error {
+		results = append(results, r)
+		return nil
+	}, batch.Options{
+		Workers:  8,
+		Ordered:  true,
+		Progress: func(p batch.Progress) { progress = append(progress, p) },
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 30)
+	assert.Len(t, progress, 30)
+	assert.Equal(t, batch.Progress{Processed: 30}, progress[29])
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		switch i % 3 {
+		case 0:
+			assert.Equal(t, melbourne, *r.Location)
+			assert.Equal(t, melbourne, r.Result.Location)
+		case 1:
+			assert.Equal(t, sydney, *r.Location)
+		case 2:
+			assert.Equal(t, "Melbourne", r.Address.FormattedAddress)
+			assert.Nil(t, r.Result)
+		}
+	}
+}
+
+func TestRunUnordered(t *testing.T) {
+	ch := make(chan batch.Query)
+	go func() {
+		for _, q := range queries(20) {
+			ch <- q
+		}
+		close(ch)
+	}()
+
+	seen := map[int]bool{}
+	err := batch.Run(context.Background(), geocoder, batch.Chan(ch), func(r batch.Result) error {
+		seen[r.Index] = true
+		return nil
+	}, batch.Options{})
+	assert.NoError(t, err)
+	assert.Len(t, seen, 20)
+}
+
+func TestRunWithErrors(t *testing.T) {
+	var last batch.Progress
+	err := batch.Run(context.Background(), failingGeocoder{}, batch.Slice(queries(5)), func(r batch.Result) error {
+		assert.Error(t, r.Err)
+		return nil
+	}, batch.Options{Progress: func(p batch.Progress) { last = p }})
+	assert.NoError(t, err)
+	assert.Equal(t, batch.Progress{Processed: 5, Failed: 5}, last)
+}
+
+func TestRunStopsOnHandlerError(t *testing.T) {
+	stop := errors.New("stop")
+	n := 0
+	err := batch.Run(context.Background(), geocoder, batch.Slice(queries(100)), func(r batch.Result) error {
+		if n++; n == 10 {
+			return stop
+		}
+		return nil
+	}, batch.Options{Ordered: true})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 10, n)
+}
+
+func TestRunStopsReadingOpenChan(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ch := make(chan batch.Query)
+	go func() { ch <- batch.Query{Address: "Melbourne"} }()
+
+	stop := errors.New("stop")
+	err := batch.Run(context.Background(), geocoder, batch.Chan(ch), func(batch.Result) error { return stop }, batch.Options{})
+	assert.Equal(t, stop, err)
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > before && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, before, runtime.NumGoroutine())
+}
+
+func TestRunReportsCheckpointError(t *testing.T) {
+	stop := errors.New("stop")
+	err := batch.Run(context.Background(), geocoder, batch.Slice(queries(5)), func(r batch.Result) error {
+		if r.Index == 2 {
+			return stop
+		}
+		return nil
+	}, batch.Options{Ordered: true, Checkpoint: failingCheckpoint{}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "stop")
+		assert.Contains(t, err.Error(), "cannot save checkpoint")
+	}
+}
+
+func TestRunWithBatchGeocoder(t *testing.T) {
+	g := &mockBatchGeocoder{Geocoder: geocoder}
+	var results []batch.Result
+	err := batch.Run(context.Background(), g, batch.Slice(queries(25)), func(r batch.Result) error {
+		results = append(results, r)
+		return nil
+	}, batch.Options{Ordered: true, BatchSize: 5})
+	assert.NoError(t, err)
+	assert.Len(t, results, 25)
+	assert.Equal(t, sydney, *results[1].Location)
+	assert.Equal(t, "Melbourne", results[2].Address.FormattedAddress)
+	assert.Equal(t, int32(5), g.batches)
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "batch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	checkpoint := batch.FileCheckpoint(filepath.Join(dir, "checkpoint"))
+
+	stop := errors.New("stop")
+	err = batch.Run(context.Background(), geocoder, batch.Slice(queries(12)), func(r batch.Result) error {
+		if r.Index == 7 {
+			return stop
+		}
+		return nil
+	}, batch.Options{Ordered: true, Checkpoint: checkpoint})
+	assert.Equal(t, stop, err)
+	n, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	var indexes []int
+	err = batch.Run(context.Background(), geocoder, batch.Slice(queries(12)), func(r batch.Result) error {
+		indexes = append(indexes, r.Index)
+		return nil
+	}, batch.Options{Ordered: true, Checkpoint: checkpoint})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7, 8, 9, 10, 11}, indexes)
+	n, err = checkpoint.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 12, n)
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := batch.Run(ctx, geocoder, batch.Slice(queries(10)), func(batch.Result) error { return nil }, batch.Options{})
+	assert.Equal(t, context.Canceled, err)
+}
+
+type failingCheckpoint struct{}
+
+func (failingCheckpoint) Load() (int, error) { return 0, nil }
+
+func (failingCheckpoint) Save(int) error { return errors.New("cannot save checkpoint") }
+
+type failingGeocoder struct{}
+
+func (failingGeocoder) Geocode(address string) (*geo.Location, error) {
+	return nil, fmt.Errorf("cannot geocode %s", address)
+}
+
+func (failingGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	return nil, fmt.Errorf("cannot reverse geocode %f,%f", lat, lng)
+}
+
+type mockBatchGeocoder struct {
+	geo.Geocoder
+	batches int32
+}
+
+func (g *mockBatchGeocoder) MaxBatchSize() int { return 10 }
+
+func (g *mockBatchGeocoder) BatchGeocode(addresses []string) ([]*geo.Location, error) {
+	atomic.AddInt32(&g.batches, 1)
+	locations := make([]*geo.Location, len(addresses))
+	for i, address := range addresses {
+		locations[i], _ = g.Geocode(address)
+	}
+	return locations, nil
+}
+
+func (g *mockBatchGeocoder) BatchReverseGeocode(locations []geo.Location) ([]*geo.Address, error) {
+	addresses := make([]*geo.Address, len(locations))
+	for i, l := range locations {
+		addresses[i], _ = g.ReverseGeocode(l.Lat, l.Lng)
+	}
+	return addresses, nil
+}
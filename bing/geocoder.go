@@ -17,6 +17,7 @@ type (
 				Point struct {
 					Coordinates []float64
 				}
+				BBox    []float64 // south, west, north, east
 				Address struct {
 					FormattedAddress string
 					AddressLine      string
@@ -32,6 +33,15 @@ type (
 	}
 )
 
+func init() {
+	geo.Register("bing", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "c", "ur"), nil
+	})
+}
+
 // Geocoder constructs Bing geocoder
 func Geocoder(key string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -47,6 +57,25 @@ func getURL(key string, baseURLs ...string) string {
 	return "http://dev.virtualearth.net/REST/v1/Locations*key=" + key
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "bing",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: "© Microsoft",
+		Licence:     "Microsoft Bing Maps Platform APIs Terms of Use",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	return strings.Replace(string(b), "*", "?q="+address+"&", 1)
 }
@@ -66,6 +95,14 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.ResourceSets) <= 0 || len(r.ResourceSets[0].Resources) <= 0 || len(r.ResourceSets[0].Resources[0].BBox) != 4 {
+		return nil
+	}
+	b := r.ResourceSets[0].Resources[0].BBox
+	return &geo.BoundingBox{South: b[0], West: b[1], North: b[2], East: b[3]}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if len(r.ErrorDetails) > 0 {
 		return nil, errors.New(strings.Join(r.ErrorDetails, " "))
@@ -80,6 +117,8 @@ func (r *geocodeResponse) Address() (*geo.Address, error) {
 		Street:           a.AddressLine,
 		City:             a.Locality,
 		Postcode:         a.PostalCode,
+		State:            a.AdminDistrict,
+		County:           a.AdminDistrict2,
 		Country:          a.CountryRegion,
 	}, nil
 }
@@ -34,6 +34,9 @@ func TestReverseGeocode(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.True(t, strings.Index(address.FormattedAddress, "Collins St") > 0)
+	assert.Equal(t, "VIC", address.State)
+	assert.Equal(t, "AU", address.CountryCode)
+	assert.Equal(t, "Australia", address.Country)
 }
 
 func TestReverseGeocodeWithNoResult(t *testing.T) {
@@ -0,0 +1,95 @@
+package geo
+
+import "math"
+
+// BoundingBox is the extent of a place, in degrees from South to North and from West to East.
+// West is greater than East when the box crosses the antimeridian
+type BoundingBox struct {
+	South, West, North, East float64
+}
+
+// world is the bounding box of the whole globe
+var world = BoundingBox{South: -90, West: -180, North: 90, East: 180}
+
+// CrossesAntimeridian tells whether b spans the 180th meridian
+func (b BoundingBox) CrossesAntimeridian() bool { return b.West > b.East }
+
+// width returns the span of b in degrees of longitude, in [0, 360]
+func (b BoundingBox) width() float64 {
+	if b.CrossesAntimeridian() {
+		return b.East - b.West + 360
+	}
+	return b.East - b.West
+}
+
+// Center returns the location in the middle of b
+func (b BoundingBox) Center() Location {
+	return Location{Lat: (b.South + b.North) / 2, Lng: WrapLongitude(b.West + b.width()/2)}
+}
+
+// containsLng tells whether lng is within b from West eastwards to East
+func (b BoundingBox) containsLng(lng float64) bool {
+	return eastOf(b.West, lng) <= b.width()
+}
+
+// Contains tells whether l is inside b, or on its edge
+func (b BoundingBox) Contains(l Location) bool {
+	return l.Lat >= b.South && l.Lat <= b.North && b.containsLng(l.Lng)
+}
+
+// Intersects tells whether b and o have any location in common
+func (b BoundingBox) Intersects(o BoundingBox) bool {
+	if b.South > o.North || o.South > b.North {
+		return false
+	}
+	return b.containsLng(o.West) || o.containsLng(b.West)
+}
+
+// covers tells whether the longitudes of b include all of those of o
+func (b BoundingBox) covers(o BoundingBox) bool {
+	return b.width() >= 360 || b.containsLng(o.West) && eastOf(b.West, o.West)+o.width() <= b.width()
+}
+
+// Union returns the smallest bounding box containing both b and o,
+// which crosses the antimeridian when that is narrower than going round the other way
+func (b BoundingBox) Union(o BoundingBox) BoundingBox {
+	u := world
+	u.South, u.North = math.Min(b.South, o.South), math.Max(b.North, o.North)
+
+	width := 360.0
+	for _, c := range []BoundingBox{b, o, {West: b.West, East: o.East}, {West: o.West, East: b.East}} {
+		if c.covers(b) && c.covers(o) && c.width() < width {
+			u.West, u.East, width = c.West, c.East, c.width()
+		}
+	}
+	return u
+}
+
+// Expand returns b grown by distance meters on every side, covering every longitude once it reaches a pole
+func (b BoundingBox) Expand(distance float64) BoundingBox {
+	d := degrees(distance / MeanRadius)
+	e := BoundingBox{South: b.South - d, North: b.North + d}
+	if e.South <= -90 || e.North >= 90 {
+		e.South, e.North = ClampLatitude(e.South), ClampLatitude(e.North)
+		e.West, e.East = world.West, world.East
+		return e
+	}
+
+	// a degree of longitude is shortest at the latitude farthest from the equator
+	dLng := d / math.Cos(radians(math.Max(math.Abs(e.South), math.Abs(e.North))))
+	if b.width()+2*dLng >= 360 {
+		e.West, e.East = world.West, world.East
+		return e
+	}
+	e.West, e.East = WrapLongitude(b.West-dLng), -WrapLongitude(-b.East-dLng)
+	return e
+}
+
+// eastOf returns how many degrees lng is east of from, in [0, 360)
+func eastOf(from, lng float64) float64 {
+	d := math.Mod(lng-from, 360)
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
@@ -0,0 +1,70 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	melbourne = geo.BoundingBox{South: -38.43, West: 144.59, North: -37.51, East: 145.51}
+	fiji      = geo.BoundingBox{South: -21.0, West: 177.0, North: -12.4, East: -178.2}
+)
+
+func TestBoundingBoxContains(t *testing.T) {
+	assert.True(t, melbourne.Contains(geo.Location{Lat: -37.8137, Lng: 144.9718}))
+	assert.True(t, melbourne.Contains(geo.Location{Lat: -38.43, Lng: 144.59}))
+	assert.False(t, melbourne.Contains(geo.Location{Lat: -33.86, Lng: 151.21}))
+
+	assert.True(t, fiji.Contains(geo.Location{Lat: -18.14, Lng: 178.44}))
+	assert.True(t, fiji.Contains(geo.Location{Lat: -16.8, Lng: -179.9}))
+	assert.True(t, fiji.Contains(geo.Location{Lat: -16.8, Lng: 180}))
+	assert.False(t, fiji.Contains(geo.Location{Lat: -16.8, Lng: 0}))
+	assert.InDelta(t, -16.7, fiji.Center().Lat, 1e-9)
+	assert.InDelta(t, 179.4, fiji.Center().Lng, 1e-9)
+}
+
+func TestBoundingBoxIntersects(t *testing.T) {
+	assert.True(t, melbourne.Intersects(geo.BoundingBox{South: -38, West: 145, North: -37, East: 146}))
+	assert.False(t, melbourne.Intersects(geo.BoundingBox{South: -38, West: 146, North: -37, East: 147}))
+	assert.False(t, melbourne.Intersects(geo.BoundingBox{South: -37, West: 145, North: -36, East: 146}))
+
+	assert.True(t, fiji.Intersects(geo.BoundingBox{South: -20, West: -179, North: -15, East: -175}))
+	assert.True(t, fiji.Intersects(geo.BoundingBox{South: -20, West: 170, North: -15, East: 178}))
+	assert.False(t, fiji.Intersects(geo.BoundingBox{South: -20, West: -170, North: -15, East: 170}))
+	assert.True(t, fiji.Intersects(geo.BoundingBox{South: -20, West: 179, North: -15, East: -179}))
+}
+
+func TestBoundingBoxUnion(t *testing.T) {
+	sydney := geo.BoundingBox{South: -34.12, West: 150.52, North: -33.58, East: 151.34}
+	assert.Equal(t, geo.BoundingBox{South: -38.43, West: 144.59, North: -33.58, East: 151.34}, melbourne.Union(sydney))
+	assert.Equal(t, melbourne, melbourne.Union(geo.BoundingBox{South: -38, West: 145, North: -38, East: 145}))
+
+	// Samoa lies east of Fiji, so the union crosses the antimeridian rather than spanning the globe
+	samoa := geo.BoundingBox{South: -14.1, West: -172.8, North: -13.4, East: -171.4}
+	assert.Equal(t, geo.BoundingBox{South: -21.0, West: 177.0, North: -12.4, East: -171.4}, fiji.Union(samoa))
+	assert.Equal(t, fiji.Union(samoa), samoa.Union(fiji))
+}
+
+func TestBoundingBoxExpand(t *testing.T) {
+	// a degree of latitude is about 111.2km, a degree of longitude is shorter by the cosine of the latitude
+	e := melbourne.Expand(111195)
+	assert.InDelta(t, -39.43, e.South, 1e-3)
+	assert.InDelta(t, -36.51, e.North, 1e-3)
+	assert.InDelta(t, 144.59-1/math.Cos(39.43*math.Pi/180), e.West, 1e-3)
+	assert.InDelta(t, 145.51+1/math.Cos(39.43*math.Pi/180), e.East, 1e-3)
+
+	e = fiji.Expand(111195 * 2)
+	assert.True(t, e.CrossesAntimeridian())
+	assert.InDelta(t, 177.0-2/math.Cos(23*math.Pi/180), e.West, 1e-3)
+
+	e = geo.BoundingBox{South: 10, West: 178, North: 11, East: 179.5}.Expand(111195)
+	assert.True(t, e.CrossesAntimeridian())
+	assert.InDelta(t, -179.48, e.East, 1e-2)
+
+	e = geo.BoundingBox{South: 89, West: 0, North: 89.5, East: 1}.Expand(111195)
+	assert.InDelta(t, 88, e.South, 1e-3)
+	assert.Equal(t, geo.BoundingBox{South: e.South, West: -180, North: 90, East: 180}, e)
+}
@@ -2,6 +2,7 @@ package cached
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/codingsince1985/geo-golang"
 	"github.com/patrickmn/go-cache"
@@ -19,31 +20,100 @@ func Geocoder(geocoder geo.Geocoder, cache *cache.Cache) geo.Geocoder {
 
 // Geocode returns location for address
 func (c cachedGeocoder) Geocode(address string) (*geo.Location, error) {
+	res, err := c.GeocodeResult(address)
+	if res == nil {
+		return nil, err
+	}
+	return &res.Location, err
+}
+
+// GeocodeResult returns location for address, with its source recording whether it was cached
+func (c cachedGeocoder) GeocodeResult(address string) (*geo.Result, error) {
 	// Check if we've cached this response
-	if cachedLoc, found := c.Cache.Get(address); found {
-		return cachedLoc.(*geo.Location), nil
+	if cached, found := c.Cache.Get(address); found {
+		switch v := cached.(type) {
+		case *geo.Result:
+			if v == nil {
+				return nil, nil
+			}
+			res := *v
+			res.Source = hit(v.Source)
+			return &res, nil
+		case *geo.Location:
+			// cached without its source
+			if v == nil {
+				return nil, nil
+			}
+			return &geo.Result{Location: *v, Source: hit(nil)}, nil
+		}
 	}
 
-	if loc, err := c.Geocoder.Geocode(address); err != nil {
-		return loc, err
-	} else {
-		c.Cache.Set(address, loc, 0)
-		return loc, nil
+	res, err := geo.GeocodeResult(c.Geocoder, address)
+	if err != nil {
+		return res, err
 	}
+	if res == nil {
+		c.set(address, (*geo.Result)(nil), nil)
+		return nil, nil
+	}
+	cached := *res
+	c.set(address, &cached, res.Source)
+	return res, nil
 }
 
-// ReverseGeocode returns address for location
+// ReverseGeocode returns address for location, with its source recording whether it was cached
 func (c cachedGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
 	// Check if we've cached this response
 	locKey := fmt.Sprintf("geo.Location{%f,%f}", lat, lng)
 	if cachedAddr, found := c.Cache.Get(locKey); found {
-		return cachedAddr.(*geo.Address), nil
+		if cachedAddr.(*geo.Address) == nil {
+			return nil, nil
+		}
+		addr := *cachedAddr.(*geo.Address)
+		addr.Source = hit(addr.Source)
+		return &addr, nil
 	}
 
-	if addr, err := c.Geocoder.ReverseGeocode(lat, lng); err != nil {
+	addr, err := c.Geocoder.ReverseGeocode(lat, lng)
+	if err != nil {
 		return nil, err
-	} else {
-		c.Cache.Set(locKey, addr, 0)
-		return addr, nil
 	}
+	if addr == nil {
+		c.set(locKey, addr, nil)
+		return nil, nil
+	}
+	cached := *addr
+	c.set(locKey, &cached, addr.Source)
+	return addr, nil
 }
+
+// hit returns a copy of s, or a new source if nil, recording that the result was cached
+func hit(s *geo.Source) *geo.Source {
+	var h geo.Source
+	if s != nil {
+		h = *s
+	}
+	h.Cached = true
+	return &h
+}
+
+// set caches v on the terms of its source, or of the geocoder cached if unknown:
+// not at all if they forbid storing it, and no longer than they allow
+func (c cachedGeocoder) set(key string, v interface{}, source *geo.Source) {
+	terms := geo.CapabilitiesOf(c.Geocoder).Terms
+	if source != nil {
+		terms = source.Terms
+	}
+	if terms.NoStore {
+		return
+	}
+	c.Cache.Set(key, v, 0)
+	if terms.MaxAge > 0 {
+		if _, expires, _ := c.Cache.GetWithExpiration(key); expires.IsZero() || time.Until(expires) > terms.MaxAge {
+			c.Cache.Set(key, v, terms.MaxAge)
+		}
+	}
+}
+
+// Capabilities returns those of the geocoder cached
+func (c cachedGeocoder) Capabilities() geo.Capabilities { return geo.CapabilitiesOf(c.Geocoder) }
@@ -83,3 +83,58 @@ func TestCachedGeocode(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, addr)
 }
+
+// terms is a geocoder whose results can be kept on terms
+type terms struct {
+	geo.Geocoder
+	terms geo.Terms
+}
+
+func (t terms) Capabilities() geo.Capabilities {
+	return geo.Capabilities{Provider: "terms", Geocode: true, Reverse: true, Terms: t.terms}
+}
+
+func TestCacheTerms(t *testing.T) {
+	next := data.Geocoder(
+		data.AddressToLocation{addressFixture: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture},
+	)
+
+	c := cache.New(cache.NoExpiration, 0)
+	noStore := cached.Geocoder(terms{Geocoder: next, terms: geo.Terms{NoStore: true}}, c)
+	_, err := noStore.Geocode(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	_, err = noStore.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c.ItemCount())
+
+	maxAge := cached.Geocoder(terms{Geocoder: next, terms: geo.Terms{MaxAge: time.Hour}}, c)
+	_, err = maxAge.Geocode(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	_, expires, found := c.GetWithExpiration(addressFixture.FormattedAddress)
+	assert.True(t, found)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expires, time.Minute)
+}
+
+func TestCacheHit(t *testing.T) {
+	c := cached.Geocoder(data.Geocoder(
+		data.AddressToLocation{addressFixture: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture},
+	), cache.New(cache.NoExpiration, 0)).(geo.ResultGeocoder)
+
+	res, err := c.GeocodeResult(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Nil(t, res.Source)
+	res, err = c.GeocodeResult(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, locationFixture, res.Location)
+	assert.Equal(t, &geo.Source{Cached: true}, res.Source)
+
+	address, err := c.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Nil(t, address.Source)
+	address, err = c.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, addressFixture.FormattedAddress, address.FormattedAddress)
+	assert.Equal(t, &geo.Source{Cached: true}, address.Source)
+}
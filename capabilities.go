@@ -0,0 +1,98 @@
+package geo
+
+import "strings"
+
+// Granularity is the finest level of the addresses found by reverse geocoding
+type Granularity string
+
+// Granularities from the finest
+const (
+	// GranularityAddress is a house number in a street
+	GranularityAddress Granularity = "address"
+	// GranularityStreet is a street
+	GranularityStreet Granularity = "street"
+	// GranularityLocality is a city, town or village
+	GranularityLocality Granularity = "locality"
+	// GranularityRegion is a state, region or country
+	GranularityRegion Granularity = "region"
+)
+
+// Capabilities are what a provider can do, how much and on which terms
+type Capabilities struct {
+	// Provider is the name of the provider, as registered
+	Provider string
+	// Geocode tells whether the provider looks up locations of addresses
+	Geocode bool
+	// Reverse tells whether the provider looks up addresses of locations
+	Reverse bool
+	// ReverseGranularity is the finest level of addresses found by reverse geocoding
+	ReverseGranularity Granularity
+	// Structured tells whether the service accepts queries split into address fields
+	Structured bool
+	// MultipleResults tells whether the provider can return all results of a query, not only the best one
+	MultipleResults bool
+	// Languages tells whether addresses can be asked in a language, with Options.Language
+	Languages bool
+	// Region tells whether results can be biased or restricted to a country, with Options.Region
+	Region bool
+	// BoundingBoxBias tells whether the service can prefer results in a bounding box
+	BoundingBoxBias bool
+	// Countries are the ISO 3166-1 alpha-2 codes of the only countries whose addresses are found, all if empty
+	Countries []string
+	// Coverage are the only areas whose locations are reverse geocoded, everywhere if empty
+	Coverage []BoundingBox
+	// MaxBatchSize is the maximum number of queries in a batch request, 0 without batch requests
+	MaxBatchSize int
+	// RateLimit is the number of requests per second the service allows by default, 0 if unknown
+	RateLimit float64
+	// Terms are those of the results
+	Terms
+}
+
+// CapableGeocoder is a Geocoder which reports its capabilities
+type CapableGeocoder interface {
+	Geocoder
+	Capabilities() Capabilities
+}
+
+// CapabilitiesOf returns the capabilities g reports, or only geocoding and reverse geocoding if it reports none
+func CapabilitiesOf(g Geocoder) Capabilities {
+	if c, ok := g.(CapableGeocoder); ok {
+		return c.Capabilities()
+	}
+	return Capabilities{Geocode: true, Reverse: true}
+}
+
+// Serves tells whether addresses in the country of countryCode can be found
+func (c Capabilities) Serves(countryCode string) bool {
+	if len(c.Countries) == 0 {
+		return true
+	}
+	for _, code := range c.Countries {
+		if strings.EqualFold(code, countryCode) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers tells whether the address of l can be found
+func (c Capabilities) Covers(l Location) bool {
+	if len(c.Coverage) == 0 {
+		return true
+	}
+	for _, b := range c.Coverage {
+		if b.Contains(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities returns those reported by the EndpointBuilder, or only geocoding and reverse geocoding if it reports none
+func (g HTTPGeocoder) Capabilities() Capabilities {
+	if c, ok := g.EndpointBuilder.(interface{ Capabilities() Capabilities }); ok {
+		return c.Capabilities()
+	}
+	return Capabilities{Geocode: true, Reverse: true}
+}
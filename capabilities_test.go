@@ -0,0 +1,32 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesOf(t *testing.T) {
+	c := geo.CapabilitiesOf(data.Geocoder(data.AddressToLocation{}, data.LocationToAddress{}))
+	assert.Equal(t, geo.Capabilities{Geocode: true, Reverse: true}, c)
+
+	c = geo.CapabilitiesOf(geo.HTTPGeocoder{EndpointBuilder: testURL("")})
+	assert.Equal(t, geo.Capabilities{Geocode: true, Reverse: true}, c)
+}
+
+func TestServesAndCovers(t *testing.T) {
+	c := geo.Capabilities{
+		Countries: []string{"FR"},
+		Coverage:  []geo.BoundingBox{{South: 41.3, West: -5.2, North: 51.1, East: 9.6}},
+	}
+	assert.True(t, c.Serves("fr"))
+	assert.False(t, c.Serves("AU"))
+	assert.True(t, c.Covers(geo.Location{Lat: 48.8566, Lng: 2.3522}))
+	assert.False(t, c.Covers(geo.Location{Lat: -37.814107, Lng: 144.96328}))
+
+	var anywhere geo.Capabilities
+	assert.True(t, anywhere.Serves("AU"))
+	assert.True(t, anywhere.Covers(geo.Location{Lat: -37.814107, Lng: 144.96328}))
+}
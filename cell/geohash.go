@@ -0,0 +1,221 @@
+// Package cell buckets geo.Location into hierarchical cells: geohash strings and a quadtree cell index,
+// with neighbour lookups and the cells covering a bounding box
+package cell
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// base32 is the alphabet of geohash, with 5 bits per character
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxCoverCells is the largest number of cells returned by a cover, beyond which it fails
+const MaxCoverCells = 1 << 20
+
+// maxCoverPrecision is the longest geohash a cover can index by row and column
+const maxCoverPrecision = 12
+
+// Direction is a compass direction to a neighbouring cell
+type Direction int
+
+// Directions to the 8 neighbours of a cell, clockwise from north
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// offsets are the rows and columns to the neighbour in each Direction
+var offsets = [...]struct{ row, col int64 }{
+	North: {1, 0}, NorthEast: {1, 1}, East: {0, 1}, SouthEast: {-1, 1},
+	South: {-1, 0}, SouthWest: {-1, -1}, West: {0, -1}, NorthWest: {1, -1},
+}
+
+// Geohash is a cell of the geohash grid, longer ones being smaller cells within the shorter ones they start with
+type Geohash string
+
+// EncodeGeohash returns the geohash of precision characters containing l
+func EncodeGeohash(l geo.Location, precision int) Geohash {
+	lat, lng := [2]float64{-90, 90}, [2]float64{-180, 180}
+	l = l.Normalize()
+
+	var b strings.Builder
+	even := true
+	for b.Len() < precision {
+		c := 0
+		for bit := 4; bit >= 0; bit-- {
+			if even {
+				c |= bisect(&lng, l.Lng) << uint(bit)
+			} else {
+				c |= bisect(&lat, l.Lat) << uint(bit)
+			}
+			even = !even
+		}
+		b.WriteByte(base32[c])
+	}
+	return Geohash(b.String())
+}
+
+// bisect halves interval to the half containing v, returning 1 for the upper half
+func bisect(interval *[2]float64, v float64) int {
+	mid := (interval[0] + interval[1]) / 2
+	if v >= mid {
+		interval[0] = mid
+		return 1
+	}
+	interval[1] = mid
+	return 0
+}
+
+// ParseGeohash returns s as a Geohash, or an error if it has characters outside the geohash alphabet
+func ParseGeohash(s string) (Geohash, error) {
+	s = strings.ToLower(s)
+	if s == "" {
+		return "", fmt.Errorf("empty geohash")
+	}
+	for _, c := range s {
+		if !strings.ContainsRune(base32, c) {
+			return "", fmt.Errorf("invalid geohash %q: unexpected %q", s, c)
+		}
+	}
+	return Geohash(s), nil
+}
+
+// BoundingBox returns the extent of h, which must be valid
+func (h Geohash) BoundingBox() geo.BoundingBox {
+	lat, lng := [2]float64{-90, 90}, [2]float64{-180, 180}
+	even := true
+	for i := 0; i < len(h); i++ {
+		c := strings.IndexByte(base32, h[i])
+		for bit := 4; bit >= 0; bit-- {
+			half := &lat
+			if even {
+				half = &lng
+			}
+			mid := (half[0] + half[1]) / 2
+			if c>>uint(bit)&1 == 1 {
+				half[0] = mid
+			} else {
+				half[1] = mid
+			}
+			even = !even
+		}
+	}
+	return geo.BoundingBox{South: lat[0], West: lng[0], North: lat[1], East: lng[1]}
+}
+
+// Location returns the center of h
+func (h Geohash) Location() geo.Location { return h.BoundingBox().Center() }
+
+// Parent returns the geohash one character shorter containing h, or h itself if it is empty
+func (h Geohash) Parent() Geohash {
+	if h == "" {
+		return h
+	}
+	return h[:len(h)-1]
+}
+
+// Children returns the 32 geohashes one character longer within h
+func (h Geohash) Children() []Geohash {
+	children := make([]Geohash, len(base32))
+	for i := range base32 {
+		children[i] = h + Geohash(base32[i])
+	}
+	return children
+}
+
+// size returns the height and width in degrees of the geohashes of precision characters
+func size(precision int) (float64, float64) {
+	bits := 5 * precision
+	return 180 / math.Exp2(float64(bits/2)), 360 / math.Exp2(float64(bits-bits/2))
+}
+
+// Neighbour returns the geohash of the same precision adjacent to h in direction d,
+// wrapping around the antimeridian, or "" beyond the poles
+func (h Geohash) Neighbour(d Direction) Geohash {
+	height, width := size(len(h))
+	c := h.Location()
+	lat := c.Lat + float64(offsets[d].row)*height
+	if lat < -90 || lat > 90 {
+		return ""
+	}
+	return EncodeGeohash(geo.Location{Lat: lat, Lng: geo.WrapLongitude(c.Lng + float64(offsets[d].col)*width)}, len(h))
+}
+
+// Neighbours returns the geohashes around h, clockwise from north, without those beyond the poles
+func (h Geohash) Neighbours() []Geohash {
+	var neighbours []Geohash
+	for d := North; d <= NorthWest; d++ {
+		if n := h.Neighbour(d); n != "" {
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours
+}
+
+// CoverGeohash returns the geohashes of precision characters covering b, west to east and south to north
+func CoverGeohash(b geo.BoundingBox, precision int) ([]Geohash, error) {
+	if precision < 1 || precision > maxCoverPrecision {
+		return nil, fmt.Errorf("geohash precision %d outside [1, %d]", precision, maxCoverPrecision)
+	}
+	height, width := size(precision)
+	var cells []Geohash
+	err := cover(b, height, width, func(lat, lng float64) {
+		cells = append(cells, EncodeGeohash(geo.Location{Lat: lat, Lng: lng}, precision))
+	})
+	return cells, err
+}
+
+// cover calls add with the center of every cell of a grid of height by width degrees covering b
+func cover(b geo.BoundingBox, height, width float64, add func(lat, lng float64)) error {
+	rows, cols := int64(math.Round(180/height)), int64(math.Round(360/width))
+	south := clamp(int64(math.Floor((b.South+90)/height)), rows-1)
+	west := clamp(int64(math.Floor((geo.WrapLongitude(b.West)+180)/width)), cols-1)
+	// cells only touching b along its north or east edge are left out
+	north := clamp(int64(math.Ceil((b.North+90)/height))-1, rows-1)
+	if north < south {
+		north = south
+	}
+	east := int64(math.Ceil((b.East+180)/width)) - 1
+	if b.East == 180 {
+		east = cols - 1
+	}
+	east = clamp((east%cols+cols)%cols, cols-1)
+	if !b.CrossesAntimeridian() && east < west {
+		east = west
+	}
+	n := ((east-west)%cols+cols)%cols + 1
+	if b.CrossesAntimeridian() && west == east {
+		n = cols
+	}
+	if (north-south+1)*n > MaxCoverCells {
+		return fmt.Errorf("cover of %d cells exceeds %d", (north-south+1)*n, MaxCoverCells)
+	}
+
+	for r := south; r <= north; r++ {
+		for i := int64(0); i < n; i++ {
+			c := (west + i) % cols
+			add(-90+(float64(r)+0.5)*height, -180+(float64(c)+0.5)*width)
+		}
+	}
+	return nil
+}
+
+func clamp(i, max int64) int64 {
+	if i < 0 {
+		return 0
+	}
+	if i > max {
+		return max
+	}
+	return i
+}
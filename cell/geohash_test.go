@@ -0,0 +1,63 @@
+package cell_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/cell"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	assert.Equal(t, cell.Geohash("u4pruydqqvj"), cell.EncodeGeohash(geo.Location{Lat: 57.64911, Lng: 10.40744}, 11))
+	assert.Equal(t, cell.Geohash("ezs42"), cell.EncodeGeohash(geo.Location{Lat: 42.6, Lng: -5.6}, 5))
+	assert.Len(t, string(cell.EncodeGeohash(geo.Location{Lat: -37.8136, Lng: 144.9631}, 20)), 20)
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	h, err := cell.ParseGeohash("EZS42")
+	assert.NoError(t, err)
+	b := h.BoundingBox()
+	assert.InDelta(t, 42.583, b.South, 1e-3)
+	assert.InDelta(t, 42.627, b.North, 1e-3)
+	assert.InDelta(t, -5.625, b.West, 1e-3)
+	assert.InDelta(t, -5.581, b.East, 1e-3)
+	assert.InDelta(t, 42.605, h.Location().Lat, 1e-3)
+	assert.InDelta(t, -5.603, h.Location().Lng, 1e-3)
+
+	_, err = cell.ParseGeohash("ezs4a")
+	assert.Error(t, err)
+}
+
+func TestGeohashNeighbours(t *testing.T) {
+	assert.Equal(t, []cell.Geohash{"dqcjw", "dqcjx", "dqcjr", "dqcjp", "dqcjn", "dqcjj", "dqcjm", "dqcjt"}, cell.Geohash("dqcjq").Neighbours())
+
+	// across the antimeridian
+	assert.Equal(t, cell.Geohash("2"), cell.Geohash("r").Neighbour(cell.East))
+	assert.Equal(t, cell.Geohash("r"), cell.Geohash("2").Neighbour(cell.West))
+
+	// beyond the north pole
+	assert.Equal(t, cell.Geohash(""), cell.Geohash("z").Neighbour(cell.North))
+	assert.Len(t, cell.Geohash("z").Neighbours(), 5)
+
+	assert.Equal(t, cell.Geohash("dqcj"), cell.Geohash("dqcjq").Parent())
+	assert.Len(t, cell.Geohash("dqcj").Children(), 32)
+}
+
+func TestCoverGeohash(t *testing.T) {
+	cells, err := cell.CoverGeohash(cell.Geohash("dqcjq").BoundingBox(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []cell.Geohash{"dqcjq"}, cells)
+
+	cells, err = cell.CoverGeohash(cell.Geohash("dqcj").BoundingBox(), 5)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, cell.Geohash("dqcj").Children(), cells)
+
+	// Fiji crosses the antimeridian, covered by cells east and west of it
+	cells, err = cell.CoverGeohash(geo.BoundingBox{South: -21.0, West: 177.0, North: -12.4, East: -178.2}, 2)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []cell.Geohash{"ru", "rv", "2h", "2j"}, cells)
+
+	_, err = cell.CoverGeohash(geo.BoundingBox{South: -90, West: -180, North: 90, East: 180}, 6)
+	assert.Error(t, err)
+}
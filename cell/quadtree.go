@@ -0,0 +1,173 @@
+package cell
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// MaxLevel is the deepest level of Cell, whose cells are about 1.9cm high
+const MaxLevel = 30
+
+// Cell is a cell of a quadtree over latitude and longitude, where each level splits a cell into 4 children.
+// Like the cell IDs of S2, it holds the path from the root interleaved in its high bits, followed by a 1 bit,
+// so that the cells within a cell are the IDs between its RangeMin and RangeMax
+type Cell uint64
+
+// CellFromLocation returns the cell of level containing l, the level being clamped to 0 to MaxLevel
+func CellFromLocation(l geo.Location, level int) Cell {
+	level = int(clamp(int64(level), MaxLevel))
+	l = l.Normalize()
+	n := float64(uint64(1) << uint(level))
+	x := uint64(math.Min(math.Floor((l.Lng+180)/360*n), n-1))
+	y := uint64(math.Min(math.Floor((l.Lat+90)/180*n), n-1))
+	return newCell(level, x, y)
+}
+
+// newCell returns the cell at column x and row y of level
+func newCell(level int, x, y uint64) Cell {
+	path := uint64(0)
+	for i := level - 1; i >= 0; i-- {
+		path = path<<2 | (y>>uint(i)&1)<<1 | x>>uint(i)&1
+	}
+	return Cell((path<<1 | 1) << uint(2*(MaxLevel-level)))
+}
+
+// CellFromToken returns the cell of a token returned by Token
+func CellFromToken(token string) (Cell, error) {
+	if token == "" || len(token) > 16 {
+		return 0, fmt.Errorf("invalid cell token %q", token)
+	}
+	id, err := strconv.ParseUint(token+strings.Repeat("0", 16-len(token)), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cell token %q: %v", token, err)
+	}
+	if c := Cell(id); c.Valid() {
+		return c, nil
+	}
+	return 0, fmt.Errorf("invalid cell token %q", token)
+}
+
+// Token returns c as hexadecimal without trailing zeros
+func (c Cell) Token() string {
+	return strings.TrimRight(fmt.Sprintf("%016x", uint64(c)), "0")
+}
+
+// Valid tells whether c is a cell of a level from 0 to MaxLevel
+func (c Cell) Valid() bool {
+	return c != 0 && bits.TrailingZeros64(uint64(c))%2 == 0 && bits.TrailingZeros64(uint64(c)) <= 2*MaxLevel
+}
+
+// Level returns the depth of c in the quadtree, 0 being the whole globe
+func (c Cell) Level() int { return MaxLevel - bits.TrailingZeros64(uint64(c))/2 }
+
+func (c Cell) lsb() uint64 { return uint64(c) & -uint64(c) }
+
+// xy returns the column and row of c in its level
+func (c Cell) xy() (uint64, uint64) {
+	path := uint64(c) >> uint(bits.TrailingZeros64(uint64(c))+1)
+	var x, y uint64
+	for i := 0; i < c.Level(); i++ {
+		x |= (path >> uint(2*i) & 1) << uint(i)
+		y |= (path >> uint(2*i+1) & 1) << uint(i)
+	}
+	return x, y
+}
+
+// RangeMin returns the first cell of MaxLevel within c
+func (c Cell) RangeMin() Cell { return Cell(uint64(c) - (c.lsb() - 1)) }
+
+// RangeMax returns the last cell of MaxLevel within c
+func (c Cell) RangeMax() Cell { return Cell(uint64(c) + (c.lsb() - 1)) }
+
+// Contains tells whether o is c or within it
+func (c Cell) Contains(o Cell) bool { return o >= c.RangeMin() && o <= c.RangeMax() }
+
+// Parent returns the cell one level up containing c, or c itself at level 0
+func (c Cell) Parent() Cell {
+	if c.Level() == 0 {
+		return c
+	}
+	lsb := c.lsb() << 2
+	return Cell(uint64(c)&-lsb | lsb)
+}
+
+// Children returns the 4 cells one level down within c, or none at MaxLevel
+func (c Cell) Children() []Cell {
+	if c.Level() == MaxLevel {
+		return nil
+	}
+	lsb := c.lsb() >> 2
+	first := uint64(c) - c.lsb() + lsb
+	return []Cell{Cell(first), Cell(first + 2*lsb), Cell(first + 4*lsb), Cell(first + 6*lsb)}
+}
+
+// BoundingBox returns the extent of c
+func (c Cell) BoundingBox() geo.BoundingBox {
+	x, y := c.xy()
+	n := float64(uint64(1) << uint(c.Level()))
+	return geo.BoundingBox{
+		South: -90 + float64(y)*180/n,
+		West:  -180 + float64(x)*360/n,
+		North: -90 + float64(y+1)*180/n,
+		East:  -180 + float64(x+1)*360/n,
+	}
+}
+
+// Location returns the center of c
+func (c Cell) Location() geo.Location { return c.BoundingBox().Center() }
+
+// Neighbour returns the cell of the same level adjacent to c in direction d,
+// wrapping around the antimeridian, or 0 beyond the poles
+func (c Cell) Neighbour(d Direction) Cell {
+	x, y := c.xy()
+	n := int64(1) << uint(c.Level())
+	row := int64(y) + offsets[d].row
+	if row < 0 || row >= n {
+		return 0
+	}
+	col := ((int64(x)+offsets[d].col)%n + n) % n
+	return newCell(c.Level(), uint64(col), uint64(row))
+}
+
+// Neighbours returns the cells around c, clockwise from north, without those beyond the poles
+// and without repeats at the coarsest levels
+func (c Cell) Neighbours() []Cell {
+	var neighbours []Cell
+	seen := map[Cell]bool{c: true}
+	for d := North; d <= NorthWest; d++ {
+		if n := c.Neighbour(d); n != 0 && !seen[n] {
+			seen[n] = true
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours
+}
+
+// String returns the level and the quadkey of c, the digits of its path from the root
+func (c Cell) String() string {
+	x, y := c.xy()
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(c.Level()) + "/")
+	for i := c.Level() - 1; i >= 0; i-- {
+		b.WriteByte(byte('0' + (y>>uint(i)&1)*2 + x>>uint(i)&1))
+	}
+	return b.String()
+}
+
+// CoverCells returns the cells of level covering b, west to east and south to north
+func CoverCells(b geo.BoundingBox, level int) ([]Cell, error) {
+	if level < 0 || level > MaxLevel {
+		return nil, fmt.Errorf("cell level %d outside [0, %d]", level, MaxLevel)
+	}
+	n := float64(uint64(1) << uint(level))
+	var cells []Cell
+	err := cover(b, 180/n, 360/n, func(lat, lng float64) {
+		cells = append(cells, CellFromLocation(geo.Location{Lat: lat, Lng: lng}, level))
+	})
+	return cells, err
+}
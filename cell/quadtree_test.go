@@ -0,0 +1,92 @@
+package cell_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/cell"
+	"github.com/stretchr/testify/assert"
+)
+
+var melbourne = geo.Location{Lat: -37.8136, Lng: 144.9631}
+
+func TestCellFromLocation(t *testing.T) {
+	root := cell.CellFromLocation(melbourne, 0)
+	assert.Equal(t, 0, root.Level())
+	assert.Equal(t, "1", root.Token())
+	assert.Equal(t, geo.BoundingBox{South: -90, West: -180, North: 90, East: 180}, root.BoundingBox())
+
+	c := cell.CellFromLocation(melbourne, 2)
+	assert.Equal(t, "2/13", c.String())
+	assert.Equal(t, geo.BoundingBox{South: -45, West: 90, North: 0, East: 180}, c.BoundingBox())
+
+	c = cell.CellFromLocation(melbourne, cell.MaxLevel)
+	assert.True(t, c.Valid())
+	assert.True(t, c.BoundingBox().Contains(melbourne))
+	assert.InDelta(t, melbourne.Lat, c.Location().Lat, 1e-6)
+	assert.InDelta(t, melbourne.Lng, c.Location().Lng, 1e-6)
+
+	token, err := cell.CellFromToken(c.Token())
+	assert.NoError(t, err)
+	assert.Equal(t, c, token)
+	_, err = cell.CellFromToken("2")
+	assert.Error(t, err)
+}
+
+func TestCellFromLocationClampsLevel(t *testing.T) {
+	assert.Equal(t, cell.CellFromLocation(melbourne, 0), cell.CellFromLocation(melbourne, -1))
+	assert.Equal(t, cell.CellFromLocation(melbourne, cell.MaxLevel), cell.CellFromLocation(melbourne, cell.MaxLevel+1))
+	assert.Equal(t, cell.CellFromLocation(melbourne, cell.MaxLevel), cell.CellFromLocation(melbourne, 64))
+	assert.True(t, cell.CellFromLocation(melbourne, 100).Valid())
+}
+
+func TestCellHierarchy(t *testing.T) {
+	c := cell.CellFromLocation(melbourne, 12)
+	for level := 12; level > 0; level-- {
+		parent := c.Parent()
+		assert.Equal(t, level-1, parent.Level())
+		assert.Equal(t, cell.CellFromLocation(melbourne, level-1), parent)
+		assert.True(t, parent.Contains(c))
+		assert.Contains(t, parent.Children(), c)
+		c = parent
+	}
+
+	children := cell.CellFromLocation(melbourne, 5).Children()
+	assert.Len(t, children, 4)
+	for _, child := range children {
+		assert.Equal(t, 6, child.Level())
+		assert.True(t, cell.CellFromLocation(melbourne, 5).BoundingBox().Contains(child.Location()))
+	}
+	assert.False(t, children[0].Contains(children[1]))
+}
+
+func TestCellNeighbours(t *testing.T) {
+	c := cell.CellFromLocation(melbourne, 10)
+	neighbours := c.Neighbours()
+	assert.Len(t, neighbours, 8)
+	for _, n := range neighbours {
+		assert.True(t, c.BoundingBox().Expand(1).Intersects(n.BoundingBox()))
+	}
+
+	east := cell.CellFromLocation(geo.Location{Lat: 0.1, Lng: 179.9}, 8).Neighbour(cell.East)
+	assert.True(t, east.BoundingBox().Contains(geo.Location{Lat: 0.1, Lng: -179.9}))
+	assert.Equal(t, cell.Cell(0), cell.CellFromLocation(geo.Location{Lat: 90}, 8).Neighbour(cell.North))
+}
+
+func TestCoverCells(t *testing.T) {
+	c := cell.CellFromLocation(melbourne, 8)
+	cells, err := cell.CoverCells(c.BoundingBox(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, cells, 16)
+	for _, child := range cells {
+		assert.True(t, c.Contains(child))
+	}
+
+	// Fiji spans 4 rows of 2.8125 degrees, in the columns either side of the antimeridian
+	cells, err = cell.CoverCells(geo.BoundingBox{South: -21.0, West: 177.0, North: -12.4, East: -178.2}, 6)
+	assert.NoError(t, err)
+	assert.Len(t, cells, 8)
+
+	_, err = cell.CoverCells(geo.BoundingBox{South: -90, West: -180, North: 90, East: 180}, 12)
+	assert.Error(t, err)
+}
@@ -1,7 +1,10 @@
 package chained
 
 import (
+	"strings"
+
 	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
 )
 
 type chainedGeocoder struct{ Geocoders []geo.Geocoder }
@@ -11,10 +14,24 @@ func Geocoder(geocoders ...geo.Geocoder) geo.Geocoder { return chainedGeocoder{G
 
 // Geocode returns location for address
 func (c chainedGeocoder) Geocode(address string) (*geo.Location, error) {
+	res, err := c.GeocodeResult(address)
+	if res == nil {
+		return nil, err
+	}
+	return &res.Location, err
+}
+
+// GeocodeResult returns location for address, with its source
+func (c chainedGeocoder) GeocodeResult(address string) (*geo.Result, error) {
 	// Geocode address by each geocoder until we get a real location response
+	countryCode := countryOf(c.Geocoders, address)
 	for i := range c.Geocoders {
-		if l, err := c.Geocoders[i].Geocode(address); err == nil && l != nil {
-			return l, nil
+		if !canGeocode(c.Geocoders[i], countryCode) {
+			continue
+		}
+		if res, err := geo.GeocodeResult(c.Geocoders[i], address); err == nil && res != nil {
+			res.Source = positioned(res.Source, i)
+			return res, nil
 		}
 		// skip error and try the next geocoder
 		continue
@@ -27,8 +44,11 @@ func (c chainedGeocoder) Geocode(address string) (*geo.Location, error) {
 func (c chainedGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
 	// Geocode address by each geocoder until we get a real location response
 	for i := range c.Geocoders {
+		if !canReverseGeocode(c.Geocoders[i], lat, lng) {
+			continue
+		}
 		if addr, err := c.Geocoders[i].ReverseGeocode(lat, lng); err == nil && addr != nil {
-			return addr, nil
+			return positionedAddress(addr, i), nil
 		}
 		// skip error and try the next geocoder
 		continue
@@ -36,3 +56,126 @@ func (c chainedGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error)
 	// No geocoders found a result
 	return nil, nil
 }
+
+type fastestGeocoder struct{ Geocoders []geo.Geocoder }
+
+// Fastest creates Geocoders looking up address all at once, and answering with the first real response
+func Fastest(geocoders ...geo.Geocoder) geo.Geocoder { return fastestGeocoder{Geocoders: geocoders} }
+
+// Geocode returns location for address
+func (f fastestGeocoder) Geocode(address string) (*geo.Location, error) {
+	res, err := f.GeocodeResult(address)
+	if res == nil {
+		return nil, err
+	}
+	return &res.Location, err
+}
+
+// GeocodeResult returns location for address, with its source
+func (f fastestGeocoder) GeocodeResult(address string) (*geo.Result, error) {
+	results := make(chan *geo.Result, len(f.Geocoders))
+	countryCode := countryOf(f.Geocoders, address)
+	for i := range f.Geocoders {
+		go func(i int, g geo.Geocoder) {
+			if !canGeocode(g, countryCode) {
+				results <- nil
+				return
+			}
+			res, err := geo.GeocodeResult(g, address)
+			if err != nil || res == nil {
+				results <- nil
+				return
+			}
+			res.Source = positioned(res.Source, i)
+			results <- res
+		}(i, f.Geocoders[i])
+	}
+	for range f.Geocoders {
+		if res := <-results; res != nil {
+			return res, nil
+		}
+	}
+	// No geocoders found a result
+	return nil, nil
+}
+
+// ReverseGeocode returns address for location
+func (f fastestGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	addresses := make(chan *geo.Address, len(f.Geocoders))
+	for i := range f.Geocoders {
+		go func(i int, g geo.Geocoder) {
+			if !canReverseGeocode(g, lat, lng) {
+				addresses <- nil
+				return
+			}
+			addr, err := g.ReverseGeocode(lat, lng)
+			if err != nil || addr == nil {
+				addresses <- nil
+				return
+			}
+			addresses <- positionedAddress(addr, i)
+		}(i, f.Geocoders[i])
+	}
+	for range f.Geocoders {
+		if addr := <-addresses; addr != nil {
+			return addr, nil
+		}
+	}
+	// No geocoders found a result
+	return nil, nil
+}
+
+// positioned returns a copy of s, or a new source if nil, recording the position of the i-th geocoder of the chain
+func positioned(s *geo.Source, i int) *geo.Source {
+	var p geo.Source
+	if s != nil {
+		p = *s
+	}
+	p.Position = i + 1
+	return &p
+}
+
+// positionedAddress returns a copy of addr whose source records the position of the i-th geocoder of the chain
+func positionedAddress(addr *geo.Address, i int) *geo.Address {
+	a := *addr
+	a.Source = positioned(addr.Source, i)
+	return &a
+}
+
+// countryOf returns the code of the country whose full name ends or starts query, or "" if none does
+// or none of geocoders is restricted to some countries. Codes are left out, as "DE" may be Delaware as well as Germany,
+// and so are countries guessed from the address, so that geocoders are only skipped for addresses they cannot find
+func countryOf(geocoders []geo.Geocoder, query string) string {
+	for _, g := range geocoders {
+		if len(geo.CapabilitiesOf(g).Countries) == 0 {
+			continue
+		}
+		p, err := address.Parse(query)
+		if err != nil || p.Country == "" || p.Confidence[address.Country] < 1 {
+			return ""
+		}
+		c, ok := geo.LookupCountry(p.Country)
+		if !ok {
+			return ""
+		}
+		for _, code := range []string{c.Alpha2, c.Alpha3, c.Numeric} {
+			if strings.EqualFold(strings.Trim(p.Country, ". "), code) {
+				return ""
+			}
+		}
+		return c.Alpha2
+	}
+	return ""
+}
+
+// canGeocode tells whether g can find addresses of the country of countryCode, any if it is ""
+func canGeocode(g geo.Geocoder, countryCode string) bool {
+	c := geo.CapabilitiesOf(g)
+	return c.Geocode && (countryCode == "" || c.Serves(countryCode))
+}
+
+// canReverseGeocode tells whether g can find the address of a location
+func canReverseGeocode(g geo.Geocoder, lat, lng float64) bool {
+	c := geo.CapabilitiesOf(g)
+	return c.Reverse && c.Covers(geo.Location{Lat: lat, Lng: lng})
+}
@@ -85,3 +85,126 @@ func TestChainedGeocode(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, addr)
 }
+
+func TestFastestGeocode(t *testing.T) {
+	mock1 := data.Geocoder(
+		data.AddressToLocation{
+			geo.Address{FormattedAddress: "Austin,TX"}: geo.Location{Lat: 1, Lng: 2},
+		},
+		data.LocationToAddress{},
+	)
+
+	mock2 := data.Geocoder(
+		data.AddressToLocation{},
+		data.LocationToAddress{
+			geo.Location{Lat: 3, Lng: 4}: geo.Address{FormattedAddress: "Dallas,TX"},
+		},
+	)
+
+	f := chained.Fastest(mock1, mock2)
+
+	l, err := f.Geocode("Austin,TX")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: 1, Lng: 2}, *l)
+
+	addr, err := f.ReverseGeocode(3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "Dallas,TX", addr.FormattedAddress)
+
+	l, err = f.Geocode("NOWHERE,TX")
+	assert.Nil(t, err)
+	assert.Nil(t, l)
+}
+
+// capable is a geocoder reporting capabilities
+type capable struct {
+	geo.Geocoder
+	capabilities geo.Capabilities
+}
+
+func (c capable) Capabilities() geo.Capabilities { return c.capabilities }
+
+func TestSkipIncapable(t *testing.T) {
+	paris := geo.Location{Lat: 48.8566, Lng: 2.3522}
+	french := capable{
+		Geocoder: data.Geocoder(
+			data.AddressToLocation{
+				geo.Address{FormattedAddress: "Paris, France"}:                   paris,
+				geo.Address{FormattedAddress: "Paris, United States of America"}: geo.Location{Lat: 1, Lng: 2},
+			},
+			data.LocationToAddress{locationFixture: geo.Address{FormattedAddress: "Paris, France"}},
+		),
+		capabilities: geo.Capabilities{
+			Geocode:   true,
+			Reverse:   true,
+			Countries: []string{"FR"},
+			Coverage:  []geo.BoundingBox{{South: 41.3, West: -5.2, North: 51.1, East: 9.6}},
+		},
+	}
+	geocodeOnly := capable{
+		Geocoder: data.Geocoder(
+			data.AddressToLocation{geo.Address{FormattedAddress: "Paris, United States of America"}: geo.Location{Lat: 33.6609, Lng: -95.5555}},
+			data.LocationToAddress{locationFixture: addressFixture},
+		),
+		capabilities: geo.Capabilities{Geocode: true},
+	}
+
+	for _, c := range []geo.Geocoder{chained.Geocoder(french, geocodeOnly), chained.Fastest(french, geocodeOnly)} {
+		l, err := c.Geocode("Paris, France")
+		assert.NoError(t, err)
+		assert.Equal(t, paris, *l)
+
+		// the French geocoder is not asked about an American address
+		l, err = c.Geocode("Paris, United States of America")
+		assert.NoError(t, err)
+		assert.Equal(t, geo.Location{Lat: 33.6609, Lng: -95.5555}, *l)
+
+		// neither geocoder is asked about Melbourne, out of France and with no reverse geocoding
+		addr, err := c.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+		assert.NoError(t, err)
+		assert.Nil(t, addr)
+	}
+}
+
+func TestSkipNotOnStateCode(t *testing.T) {
+	wilmington := geo.Location{Lat: 39.7391, Lng: -75.5398}
+	american := capable{
+		Geocoder: data.Geocoder(
+			data.AddressToLocation{geo.Address{FormattedAddress: "1 Market St, Wilmington, DE"}: wilmington},
+			data.LocationToAddress{},
+		),
+		capabilities: geo.Capabilities{Geocode: true, Reverse: true, Countries: []string{"US", "CA"}},
+	}
+
+	// DE is Delaware as much as Germany, so the American geocoder is asked
+	for _, c := range []geo.Geocoder{chained.Geocoder(american), chained.Fastest(american)} {
+		l, err := c.Geocode("1 Market St, Wilmington, DE")
+		assert.NoError(t, err)
+		if assert.NotNil(t, l) {
+			assert.Equal(t, wilmington, *l)
+		}
+	}
+}
+
+func TestGeocodeResultSource(t *testing.T) {
+	osm := capable{
+		Geocoder: data.Geocoder(data.AddressToLocation{addressFixture: locationFixture}, data.LocationToAddress{}),
+		capabilities: geo.Capabilities{
+			Provider: "osm",
+			Geocode:  true,
+			Reverse:  true,
+			Terms:    geo.Terms{Attribution: "© OpenStreetMap contributors", Licence: "ODbL-1.0"},
+		},
+	}
+	empty := data.Geocoder(data.AddressToLocation{}, data.LocationToAddress{})
+	// the source records the position of the geocoder which found the result
+	for _, test := range []struct {
+		geocoder geo.Geocoder
+		position int
+	}{{chained.Geocoder(empty, osm), 2}, {chained.Fastest(empty, osm), 2}} {
+		res, err := test.geocoder.(geo.ResultGeocoder).GeocodeResult(addressFixture.FormattedAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, locationFixture, res.Location)
+		assert.Equal(t, &geo.Source{Provider: "osm", Terms: osm.capabilities.Terms, Position: test.position}, res.Source)
+	}
+}
@@ -0,0 +1,119 @@
+// Command geo-server serves geocoding over HTTP with a chain of geo-golang providers,
+// for services which are not written in Go.
+//
+//	geo-server -addr :8080 -provider osm,google -key GOOGLE_API_KEY=... -cache 24h -rate 1
+//
+// It answers
+//
+//	GET  /geocode?q=address            location, bounding box and timezone of an address
+//	GET  /reverse?lat=...&lng=...      address of a location
+//	POST /batch                        {"addresses": [...]} or {"locations": [{"lat": ..., "lng": ...}]}
+//	GET  /healthz                      200 while serving
+//	GET  /metrics                      request counts and durations in the Prometheus text format
+//
+// With -nominatim, /search and /reverse answer like Nominatim instead, so that its clients can use the server unchanged.
+// Keys of providers are set with -key, or in the environment.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/cached"
+	"github.com/codingsince1985/geo-golang/chained"
+	"github.com/codingsince1985/geo-golang/cmd/internal/providers"
+	"github.com/codingsince1985/geo-golang/ratelimited"
+	"github.com/patrickmn/go-cache"
+)
+
+func main() {
+	var (
+		keys            = providers.KeyFlags{}
+		addr            = flag.String("addr", ":8080", "`address` to listen on")
+		provider        = flag.String("provider", "osm", "`names` of the providers, separated by commas to fall back on the next")
+		baseURL         = flag.String("url", "", "base `URL` replacing that of a single provider, such as a self-hosted Nominatim")
+		ttl             = flag.Duration("cache", 0, "cache results for `duration`, such as 24h")
+		rate            = flag.Float64("rate", 0, "at most `n` requests a second to each provider")
+		nominatim       = flag.Bool("nominatim", false, "answer /search and /reverse like Nominatim")
+		workers         = flag.Int("workers", 4, "`n` lookups at the same time for each /batch request")
+		maxBatch        = flag.Int("max-batch", 1000, "at most `n` queries in a /batch request")
+		shutdownTimeout = flag.Duration("shutdown-timeout", 30*time.Second, "`duration` to finish the requests in progress on SIGINT or SIGTERM")
+	)
+	flag.Var(keys, "key", "key of a provider as `NAME=value`, such as GOOGLE_API_KEY=...; repeatable")
+	flag.Parse()
+
+	geocoder, err := newGeocoder(*provider, *baseURL, providers.Lookup(keys, os.Getenv, nil), *ttl, *rate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := newServer(geocoder, *nominatim, *workers, *maxBatch)
+
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      s.routes(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 5 * time.Minute,
+	}
+	stopped := make(chan error, 1)
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		log.Print("shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		stopped <- srv.Shutdown(ctx)
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	if err := <-stopped; err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newGeocoder returns the chain of the providers of names, each rate limited to rate requests a second if positive,
+// with results cached for ttl if positive
+func newGeocoder(names, baseURL string, key func(string) string, ttl time.Duration, rate float64) (geo.Geocoder, error) {
+	var baseURLs []string
+	if baseURL != "" {
+		if strings.Contains(names, ",") {
+			return nil, errors.New("-url needs a single provider")
+		}
+		baseURLs = []string{baseURL}
+	}
+	var geocoders []geo.Geocoder
+	for _, name := range strings.Split(names, ",") {
+		g, err := providers.New(name, key, baseURLs...)
+		if err != nil {
+			return nil, err
+		}
+		if rate > 0 {
+			g = ratelimited.Geocoder(g, rate)
+		}
+		geocoders = append(geocoders, g)
+	}
+	if len(geocoders) == 0 {
+		return nil, fmt.Errorf("no provider in %q", names)
+	}
+	geocoder := geocoders[0]
+	if len(geocoders) > 1 {
+		geocoder = chained.Geocoder(geocoders...)
+	}
+	if ttl > 0 {
+		geocoder = cached.Geocoder(geocoder, cache.New(ttl, ttl))
+	}
+	return geocoder, nil
+}
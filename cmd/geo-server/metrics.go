@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// metrics counts the requests of each endpoint by status, their durations, and the failed lookups
+type metrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]int
+	durations map[string]time.Duration
+	timeouts  int
+	failures  int
+}
+
+type requestKey struct {
+	endpoint string
+	status   int
+}
+
+func newMetrics() *metrics {
+	return &metrics{requests: map[requestKey]int{}, durations: map[string]time.Duration{}}
+}
+
+// statusRecorder keeps the status written to a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// instrument counts the requests of endpoint handled by h
+func (m *metrics) instrument(endpoint string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, req)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.requests[requestKey{endpoint: endpoint, status: rec.status}]++
+		m.durations[endpoint] += time.Since(start)
+	})
+}
+
+// failed counts a lookup which failed with err
+func (m *metrics) failed(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == geo.ErrTimeout {
+		m.timeouts++
+	} else if err != errNotFound {
+		m.failures++
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format
+func (m *metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(m.requests))
+	counts := map[string]int{}
+	for k, n := range m.requests {
+		keys = append(keys, k)
+		counts[k.endpoint] += n
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].status < keys[j].status
+	})
+	endpoints := make([]string, 0, len(m.durations))
+	for e := range m.durations {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# HELP geo_requests_total Requests by endpoint and status.")
+	fmt.Fprintln(w, "# TYPE geo_requests_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(w, "geo_requests_total{endpoint=%q,status=\"%d\"} %d\n", k.endpoint, k.status, m.requests[k])
+	}
+	fmt.Fprintln(w, "# HELP geo_request_duration_seconds Time spent answering requests by endpoint.")
+	fmt.Fprintln(w, "# TYPE geo_request_duration_seconds summary")
+	for _, e := range endpoints {
+		fmt.Fprintf(w, "geo_request_duration_seconds_sum{endpoint=%q} %s\n", e, strconv.FormatFloat(m.durations[e].Seconds(), 'f', -1, 64))
+		fmt.Fprintf(w, "geo_request_duration_seconds_count{endpoint=%q} %d\n", e, counts[e])
+	}
+	fmt.Fprintln(w, "# HELP geo_lookup_failures_total Lookups failed by the providers, by reason.")
+	fmt.Fprintln(w, "# TYPE geo_lookup_failures_total counter")
+	fmt.Fprintf(w, "geo_lookup_failures_total{reason=\"timeout\"} %d\n", m.timeouts)
+	fmt.Fprintf(w, "geo_lookup_failures_total{reason=\"error\"} %d\n", m.failures)
+}
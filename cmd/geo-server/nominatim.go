@@ -0,0 +1,132 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/osm"
+)
+
+// defaultLicence is the licence of the results of Nominatim mode whose provider does not tell its terms
+const defaultLicence = "Data from the providers of geo-server, under their own terms"
+
+// nominatimPlace is a result of the Nominatim API, with coordinates as strings like Nominatim
+type nominatimPlace struct {
+	PlaceID     int             `json:"place_id"`
+	Licence     string          `json:"licence"`
+	Lat         string          `json:"lat"`
+	Lon         string          `json:"lon"`
+	DisplayName string          `json:"display_name"`
+	BoundingBox osm.BoundingBox `json:"boundingbox,omitempty"`
+	Address     *osm.Address    `json:"address,omitempty"`
+}
+
+// nominatimError is the body with which Nominatim answers a query it cannot geocode
+type nominatimError struct {
+	Error string `json:"error"`
+}
+
+func formatCoordinate(f float64) string { return strconv.FormatFloat(f, 'f', 7, 64) }
+
+// newNominatimPlace returns the place at l with address a if not nil, from source
+func newNominatimPlace(l geo.Location, a *geo.Address, source *geo.Source) nominatimPlace {
+	place := nominatimPlace{Licence: licence(source), Lat: formatCoordinate(l.Lat), Lon: formatCoordinate(l.Lng)}
+	if a != nil {
+		place.DisplayName = a.FormattedAddress
+		place.Address = &osm.Address{
+			HouseNumber:   a.HouseNumber,
+			Road:          a.Street,
+			Suburb:        a.Suburb,
+			City:          a.City,
+			County:        a.County,
+			State:         a.State,
+			StateDistrict: a.StateDistrict,
+			Postcode:      a.Postcode,
+			Country:       a.Country,
+			CountryCode:   strings.ToLower(a.CountryCode),
+		}
+	}
+	return place
+}
+
+// licence returns the attribution and licence of the results of source, as Nominatim tells those of OpenStreetMap
+func licence(source *geo.Source) string {
+	if source == nil || source.Attribution == "" && source.Licence == "" {
+		return defaultLicence
+	}
+	if source.Attribution == "" || source.Licence == "" {
+		return source.Attribution + source.Licence
+	}
+	return source.Attribution + ", " + source.Licence
+}
+
+// nominatimQuery returns the free-form q of req, or its structured parameters joined together
+func nominatimQuery(req *http.Request) string {
+	q := req.URL.Query()
+	if query := strings.TrimSpace(q.Get("q")); query != "" {
+		return query
+	}
+	var parts []string
+	for _, p := range []string{"amenity", "street", "city", "county", "state", "postalcode", "country"} {
+		if v := strings.TrimSpace(q.Get(p)); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nominatimSearch answers /search like Nominatim, with an array of at most one place
+func (s *server) nominatimSearch(w http.ResponseWriter, req *http.Request) {
+	query := nominatimQuery(req)
+	if query == "" {
+		writeJSON(w, http.StatusBadRequest, nominatimError{Error: "Nothing to search for"})
+		return
+	}
+	r, err := s.lookup(query)
+	if err == errNotFound {
+		writeJSON(w, http.StatusOK, []nominatimPlace{})
+		return
+	}
+	if err != nil {
+		s.metrics.failed(err)
+		writeJSON(w, statusOf(err), nominatimError{Error: err.Error()})
+		return
+	}
+	// Nominatim clients show the address of the place found, which most providers supply with its location
+	a := r.Address
+	if a == nil {
+		if a, err = s.geocoder.ReverseGeocode(r.Location.Lat, r.Location.Lng); err != nil {
+			a = nil
+		}
+	}
+	place := newNominatimPlace(r.Location, a, r.Source)
+	if a == nil {
+		place.DisplayName = query
+	}
+	if b := r.BoundingBox; b != nil {
+		place.BoundingBox = osm.BoundingBox{formatCoordinate(b.South), formatCoordinate(b.North), formatCoordinate(b.West), formatCoordinate(b.East)}
+	}
+	writeJSON(w, http.StatusOK, []nominatimPlace{place})
+}
+
+// nominatimReverse answers /reverse like Nominatim, with the place found or an error in a successful response
+func (s *server) nominatimReverse(w http.ResponseWriter, req *http.Request) {
+	l, err := parseLocation(req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, nominatimError{Error: err.Error()})
+		return
+	}
+	a, err := s.geocoder.ReverseGeocode(l.Lat, l.Lng)
+	if err != nil {
+		s.metrics.failed(err)
+		writeJSON(w, statusOf(err), nominatimError{Error: err.Error()})
+		return
+	}
+	if a == nil {
+		writeJSON(w, http.StatusOK, nominatimError{Error: "Unable to geocode"})
+		return
+	}
+	writeJSON(w, http.StatusOK, newNominatimPlace(l, a, a.Source))
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/batch"
+)
+
+// server answers geocoding requests with a geocoder
+type server struct {
+	geocoder  geo.Geocoder
+	nominatim bool
+	workers   int
+	maxBatch  int
+	metrics   *metrics
+}
+
+func newServer(geocoder geo.Geocoder, nominatim bool, workers, maxBatch int) *server {
+	return &server{geocoder: geocoder, nominatim: nominatim, workers: workers, maxBatch: maxBatch, metrics: newMetrics()}
+}
+
+// routes returns the handler of all endpoints, counting their requests in the metrics
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(path string, h http.HandlerFunc) { mux.Handle(path, s.metrics.instrument(path, h)) }
+	handle("/geocode", s.geocode)
+	handle("/batch", s.batch)
+	if s.nominatim {
+		handle("/search", s.nominatimSearch)
+		handle("/reverse", s.nominatimReverse)
+	} else {
+		handle("/reverse", s.reverse)
+	}
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("/metrics", s.metrics)
+	return mux
+}
+
+type location struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type boundingBox struct {
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+	North float64 `json:"north"`
+	East  float64 `json:"east"`
+}
+
+type address struct {
+	FormattedAddress string `json:"formattedAddress,omitempty"`
+	Street           string `json:"street,omitempty"`
+	HouseNumber      string `json:"houseNumber,omitempty"`
+	Suburb           string `json:"suburb,omitempty"`
+	Postcode         string `json:"postcode,omitempty"`
+	State            string `json:"state,omitempty"`
+	StateDistrict    string `json:"stateDistrict,omitempty"`
+	County           string `json:"county,omitempty"`
+	Country          string `json:"country,omitempty"`
+	CountryCode      string `json:"countryCode,omitempty"`
+	City             string `json:"city,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
+}
+
+// source is the provider of a result, the terms of its use, and how it was found
+type source struct {
+	Provider    string  `json:"provider,omitempty"`
+	Attribution string  `json:"attribution,omitempty"`
+	Licence     string  `json:"licence,omitempty"`
+	NoStore     bool    `json:"noStore,omitempty"`
+	MaxAge      int64   `json:"maxAge,omitempty"` // in seconds
+	URL         string  `json:"url,omitempty"`
+	Status      int     `json:"status,omitempty"`
+	Latency     float64 `json:"latency,omitempty"` // in seconds
+	Cached      bool    `json:"cached,omitempty"`
+	Position    int     `json:"position,omitempty"`
+	// Raw is the response of the provider, if it is configured to keep it
+	Raw json.RawMessage `json:"raw,omitempty"`
+}
+
+func newSource(s *geo.Source) *source {
+	if s == nil {
+		return nil
+	}
+	return &source{
+		Provider: s.Provider, Attribution: s.Attribution, Licence: s.Licence,
+		NoStore: s.NoStore, MaxAge: int64(s.MaxAge / time.Second),
+		URL: s.URL, Status: s.Status, Latency: s.Latency.Seconds(), Cached: s.Cached, Position: s.Position,
+		Raw: s.Raw,
+	}
+}
+
+// geocoded is the response of /geocode, and of each address of /batch
+type geocoded struct {
+	Query       string       `json:"query"`
+	Location    *location    `json:"location,omitempty"`
+	BoundingBox *boundingBox `json:"boundingBox,omitempty"`
+	Timezone    string       `json:"timezone,omitempty"`
+	Source      *source      `json:"source,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// reversed is the response of /reverse, and of each location of /batch
+type reversed struct {
+	Location location `json:"location"`
+	Address  *address `json:"address,omitempty"`
+	Source   *source  `json:"source,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// errNotFound is the error of a query found by no provider
+var errNotFound = errors.New("not found")
+
+// writeJSON writes v as the body of the response, without the trailing newline of a json.Encoder
+// which some Nominatim clients do not expect
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// statusOf returns the status of the response to a lookup failing with err
+func statusOf(err error) int {
+	switch {
+	case err == errNotFound:
+		return http.StatusNotFound
+	case err == geo.ErrTimeout:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// lookup geocodes query, with its bounding box, timezone and source if the geocoder supplies them
+func (s *server) lookup(query string) (*geo.Result, error) {
+	r, err := geo.GeocodeResult(s.geocoder, query)
+	if err == nil && r == nil {
+		err = errNotFound
+	}
+	return r, err
+}
+
+func newGeocoded(query string, r *geo.Result, err error) geocoded {
+	g := geocoded{Query: query, Error: errorString(err)}
+	if r != nil {
+		g.Location, g.Timezone = &location{Lat: r.Location.Lat, Lng: r.Location.Lng}, r.Timezone
+		g.Source = newSource(r.Source)
+		if b := r.BoundingBox; b != nil {
+			g.BoundingBox = &boundingBox{South: b.South, West: b.West, North: b.North, East: b.East}
+		}
+	}
+	return g
+}
+
+func newReversed(l geo.Location, a *geo.Address, err error) reversed {
+	r := reversed{Location: location{Lat: l.Lat, Lng: l.Lng}, Error: errorString(err)}
+	if a != nil {
+		r.Address = &address{
+			FormattedAddress: a.FormattedAddress, Street: a.Street, HouseNumber: a.HouseNumber, Suburb: a.Suburb,
+			Postcode: a.Postcode, State: a.State, StateDistrict: a.StateDistrict, County: a.County,
+			Country: a.Country, CountryCode: a.CountryCode, City: a.City, Timezone: a.Timezone,
+		}
+		r.Source = newSource(a.Source)
+	}
+	return r
+}
+
+// geocode answers /geocode?q=address
+func (s *server) geocode(w http.ResponseWriter, req *http.Request) {
+	query := strings.TrimSpace(req.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing q"))
+		return
+	}
+	r, err := s.lookup(query)
+	status := http.StatusOK
+	if err != nil {
+		status = statusOf(err)
+		s.metrics.failed(err)
+	}
+	writeJSON(w, status, newGeocoded(query, r, err))
+}
+
+// parseLocation returns the location in the lat and lng, or lon, parameters of req
+func parseLocation(req *http.Request) (geo.Location, error) {
+	q := req.URL.Query()
+	lng := q.Get("lng")
+	if lng == "" {
+		lng = q.Get("lon")
+	}
+	lat, errLat := strconv.ParseFloat(q.Get("lat"), 64)
+	lon, errLng := strconv.ParseFloat(lng, 64)
+	if errLat != nil || errLng != nil {
+		return geo.Location{}, errors.New("lat and lng must be numbers")
+	}
+	l := geo.Location{Lat: lat, Lng: lon}
+	return l, l.Validate()
+}
+
+// reverse answers /reverse?lat=...&lng=...
+func (s *server) reverse(w http.ResponseWriter, req *http.Request) {
+	l, err := parseLocation(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	a, err := s.geocoder.ReverseGeocode(l.Lat, l.Lng)
+	if err == nil && a == nil {
+		err = errNotFound
+	}
+	status := http.StatusOK
+	if err != nil {
+		status = statusOf(err)
+		s.metrics.failed(err)
+	}
+	writeJSON(w, status, newReversed(l, a, err))
+}
+
+// batchRequest is the body of /batch, with either addresses or locations
+type batchRequest struct {
+	Addresses []string   `json:"addresses"`
+	Locations []location `json:"locations"`
+}
+
+// batch answers POST /batch, looking up the queries with s.workers at the same time,
+// or with the batch endpoint of a geo.BatchGeocoder, and answering each in the order of the request
+func (s *server) batch(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("POST a JSON body"))
+		return
+	}
+	var body batchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 8<<20)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(body.Addresses) > 0 && len(body.Locations) > 0 {
+		writeError(w, http.StatusBadRequest, errors.New("addresses and locations cannot be in the same batch"))
+		return
+	}
+	if n := len(body.Addresses) + len(body.Locations); n > s.maxBatch {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("%d queries exceed the maximum of %d", n, s.maxBatch))
+		return
+	}
+
+	queries := make([]batch.Query, 0, len(body.Addresses)+len(body.Locations))
+	for i, a := range body.Addresses {
+		// an empty address would be taken for the location 0, 0
+		if strings.TrimSpace(a) == "" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("empty address at %d", i))
+			return
+		}
+		queries = append(queries, batch.Query{Address: a})
+	}
+	for _, l := range body.Locations {
+		queries = append(queries, batch.Query{Location: geo.Location{Lat: l.Lat, Lng: l.Lng}})
+	}
+
+	var results []interface{}
+	err := batch.Run(req.Context(), s.geocoder, batch.Slice(queries), func(r batch.Result) error {
+		err := r.Err
+		if err != nil {
+			s.metrics.failed(err)
+		}
+		if r.Query.Address != "" {
+			if err == nil && r.Location == nil {
+				err = errNotFound
+			}
+			results = append(results, newGeocoded(r.Query.Address, r.Result, err))
+			return nil
+		}
+		if err == nil && r.Address == nil {
+			err = errNotFound
+		}
+		results = append(results, newReversed(r.Query.Location, r.Address, err))
+		return nil
+	}, batch.Options{Workers: s.workers, Ordered: true})
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	if results == nil {
+		results = []interface{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
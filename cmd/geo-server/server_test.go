@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/codingsince1985/geo-golang/openstreetmap"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addressFixture = geo.Address{
+		FormattedAddress: "64 Elizabeth Street, Melbourne, Victoria 3000, Australia",
+		HouseNumber:      "64",
+		Street:           "Elizabeth Street",
+		Postcode:         "3000",
+		City:             "Melbourne",
+		State:            "Victoria",
+		Country:          "Australia",
+		CountryCode:      "AU",
+	}
+	locationFixture = geo.Location{Lat: -37.814107, Lng: 144.96328}
+	geocoder        = data.Geocoder(
+		data.AddressToLocation{geo.Address{FormattedAddress: addressFixture.FormattedAddress}: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture},
+	)
+)
+
+func get(t *testing.T, url string, v interface{}) int {
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+func TestGeocode(t *testing.T) {
+	ts := httptest.NewServer(newServer(geocoder, false, 2, 10).routes())
+	defer ts.Close()
+
+	var g geocoded
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+"/geocode?q=64+Elizabeth+Street%2C+Melbourne%2C+Victoria+3000%2C+Australia", &g))
+	assert.Equal(t, &location{Lat: -37.814107, Lng: 144.96328}, g.Location)
+
+	g = geocoded{}
+	assert.Equal(t, http.StatusNotFound, get(t, ts.URL+"/geocode?q=Atlantis", &g))
+	assert.Equal(t, geocoded{Query: "Atlantis", Error: "not found"}, g)
+
+	var e map[string]string
+	assert.Equal(t, http.StatusBadRequest, get(t, ts.URL+"/geocode", &e))
+}
+
+func TestReverse(t *testing.T) {
+	ts := httptest.NewServer(newServer(geocoder, false, 2, 10).routes())
+	defer ts.Close()
+
+	var r reversed
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+"/reverse?lat=-37.814107&lng=144.96328", &r))
+	assert.Equal(t, "Elizabeth Street", r.Address.Street)
+	assert.Equal(t, "AU", r.Address.CountryCode)
+
+	r = reversed{}
+	assert.Equal(t, http.StatusNotFound, get(t, ts.URL+"/reverse?lat=0&lng=0", &r))
+	assert.Nil(t, r.Address)
+
+	var e map[string]string
+	assert.Equal(t, http.StatusBadRequest, get(t, ts.URL+"/reverse?lat=100&lng=0", &e))
+}
+
+func TestBatch(t *testing.T) {
+	ts := httptest.NewServer(newServer(geocoder, false, 2, 3).routes())
+	defer ts.Close()
+
+	post := func(body string, v interface{}) int {
+		resp, err := http.Post(ts.URL+"/batch", "application/json", strings.NewReader(body))
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+		return resp.StatusCode
+	}
+
+	var geocodedResults struct{ Results []geocoded }
+	assert.Equal(t, http.StatusOK, post(`{"addresses": ["Atlantis", "`+addressFixture.FormattedAddress+`"]}`, &geocodedResults))
+	assert.Equal(t, []geocoded{
+		{Query: "Atlantis", Error: "not found"},
+		{Query: addressFixture.FormattedAddress, Location: &location{Lat: -37.814107, Lng: 144.96328}},
+	}, geocodedResults.Results)
+
+	// with the source of the result, as /geocode
+	ts = httptest.NewServer(newServer(licensed{geocoder}, false, 2, 3).routes())
+	defer ts.Close()
+	geocodedResults.Results = nil
+	assert.Equal(t, http.StatusOK, post(`{"addresses": ["`+addressFixture.FormattedAddress+`"]}`, &geocodedResults))
+	if assert.Len(t, geocodedResults.Results, 1) && assert.NotNil(t, geocodedResults.Results[0].Source) {
+		assert.Equal(t, "test", geocodedResults.Results[0].Source.Provider)
+		assert.Equal(t, "© Test", geocodedResults.Results[0].Source.Attribution)
+	}
+
+	var reversedResults struct{ Results []reversed }
+	assert.Equal(t, http.StatusOK, post(`{"locations": [{"lat": -37.814107, "lng": 144.96328}]}`, &reversedResults))
+	assert.Len(t, reversedResults.Results, 1)
+	assert.Equal(t, addressFixture.FormattedAddress, reversedResults.Results[0].Address.FormattedAddress)
+
+	var e map[string]string
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(`{"addresses": ["a", "b", "c", "d"]}`, &e))
+	assert.Equal(t, http.StatusBadRequest, post(`{"addresses": [""]}`, &e))
+	assert.Equal(t, http.StatusBadRequest, post(`{"addresses": ["a"], "locations": [{"lat": 0, "lng": 0}]}`, &e))
+}
+
+func TestNominatim(t *testing.T) {
+	ts := httptest.NewServer(newServer(geocoder, true, 2, 10).routes())
+	defer ts.Close()
+
+	// a Nominatim client works with the server unchanged
+	client := openstreetmap.GeocoderWithURL(ts.URL + "/")
+	l, err := client.Geocode(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, locationFixture, *l)
+
+	l, err = client.Geocode("Atlantis")
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+
+	a, err := client.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, "osm", a.Source.Provider)
+	a.Source = nil
+	assert.Equal(t, addressFixture, *a)
+
+	_, err = client.ReverseGeocode(0, 0)
+	assert.Error(t, err)
+}
+
+// licensed is a geocoder reporting the terms of its results
+type licensed struct{ geo.Geocoder }
+
+func (licensed) Capabilities() geo.Capabilities {
+	return geo.Capabilities{Provider: "test", Geocode: true, Reverse: true, Terms: geo.Terms{Attribution: "© Test", Licence: "CC-BY-4.0"}}
+}
+
+func TestNominatimPlace(t *testing.T) {
+	ts := httptest.NewServer(newServer(licensed{geocoder}, true, 2, 10).routes())
+	defer ts.Close()
+
+	// the place is named by its address, not by the query, and comes with the terms of the provider
+	var places []map[string]interface{}
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+"/search?format=json&q="+url.QueryEscape(addressFixture.FormattedAddress), &places))
+	if assert.Len(t, places, 1) {
+		assert.Equal(t, addressFixture.FormattedAddress, places[0]["display_name"])
+		assert.Equal(t, "© Test, CC-BY-4.0", places[0]["licence"])
+	}
+
+	ts = httptest.NewServer(newServer(data.Geocoder(
+		data.AddressToLocation{geo.Address{FormattedAddress: "64 Elizabeth St"}: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture},
+	), true, 2, 10).routes())
+	defer ts.Close()
+	places = nil
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+"/search?format=json&q=64+Elizabeth+St", &places))
+	if assert.Len(t, places, 1) {
+		assert.Equal(t, addressFixture.FormattedAddress, places[0]["display_name"])
+		assert.Equal(t, defaultLicence, places[0]["licence"])
+	}
+}
+
+func TestHealthAndMetrics(t *testing.T) {
+	ts := httptest.NewServer(newServer(geocoder, false, 2, 10).routes())
+	defer ts.Close()
+
+	var health map[string]string
+	assert.Equal(t, http.StatusOK, get(t, ts.URL+"/healthz", &health))
+	assert.Equal(t, "ok", health["status"])
+
+	var g geocoded
+	get(t, ts.URL+"/geocode?q=Atlantis", &g)
+	get(t, ts.URL+"/geocode?q="+strings.Replace(addressFixture.FormattedAddress, " ", "+", -1), &g)
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `geo_requests_total{endpoint="/geocode",status="200"} 1`)
+	assert.Contains(t, string(body), `geo_requests_total{endpoint="/geocode",status="404"} 1`)
+	assert.Contains(t, string(body), `geo_request_duration_seconds_count{endpoint="/geocode"} 2`)
+	assert.Contains(t, string(body), `geo_lookup_failures_total{reason="error"} 0`)
+}
+
+func TestNewGeocoder(t *testing.T) {
+	key := func(string) string { return "" }
+	_, err := newGeocoder("osm,frenchapigouv", "", key, 0, 1)
+	assert.NoError(t, err)
+	_, err = newGeocoder("osm,google", "", key, 0, 0)
+	assert.Error(t, err)
+	_, err = newGeocoder("osm,frenchapigouv", "http://localhost/", key, 0, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,599 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
+	"github.com/codingsince1985/geo-golang/cached"
+	"github.com/patrickmn/go-cache"
+)
+
+// outputColumns are the columns the batch command adds to those of the input
+var outputColumns = []string{"lat", "lng", "formatted_address", "provider", "confidence"}
+
+// batchOptions are the flags of the batch command
+type batchOptions struct {
+	options
+	in, out, inputFormat string
+	query                string
+	structured           map[address.Field]*string
+	workers              int
+	resume               bool
+	reverse              bool
+}
+
+// structuredFields are the address fields whose columns can make up a structured query, by flag
+var structuredFields = []struct {
+	flag  string
+	field address.Field
+}{
+	{"house-number", address.HouseNumber},
+	{"street", address.Street},
+	{"suburb", address.Suburb},
+	{"city", address.City},
+	{"postcode", address.Postcode},
+	{"state", address.State},
+	{"country", address.Country},
+}
+
+// row is a row of the input, with its values by column
+type row struct {
+	index  int
+	record []string               // in CSV
+	object map[string]interface{} // in JSON Lines
+	values map[string]string
+}
+
+// outcome is the result of geocoding a row
+type outcome struct {
+	location   *geo.Location
+	formatted  string
+	provider   string
+	confidence *float64
+	err        error
+}
+
+const batchUsage = `usage: geo batch [flags] -query columns | -street column ...
+
+Geocodes each row of a CSV file with a header, or of a JSON Lines file, writing its columns followed by
+lat, lng, formatted_address, provider and confidence, in the format of the input. Rows are written in order,
+so that -resume carries on after those already in -out.
+
+`
+
+// runBatch runs the batch command
+func runBatch(args []string, env func(string) string, stdin io.Reader, stdout, stderr io.Writer) error {
+	b := batchOptions{structured: map[address.Field]*string{}}
+	fs := newFlagSet("batch", &b.options, stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, batchUsage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&b.in, "in", "", "input `file`, instead of the standard input")
+	fs.StringVar(&b.out, "out", "", "output `file`, instead of the standard output")
+	fs.StringVar(&b.inputFormat, "input-format", "", "`format` of the input: csv or jsonl (default by the extension of -in, or csv)")
+	fs.StringVar(&b.query, "query", "", "`columns` joined by commas into a free-form query")
+	for _, f := range structuredFields {
+		b.structured[f.field] = fs.String(f.flag, "", "`column` of the "+strings.Replace(f.flag, "-", " ", -1)+" of a structured query")
+	}
+	fs.IntVar(&b.workers, "workers", 4, "`n` rows geocoded at the same time")
+	fs.BoolVar(&b.resume, "resume", false, "skip the rows already in -out, after an interrupted run")
+	fs.BoolVar(&b.reverse, "reverse", false, "reverse geocode the locations found for their address, one more request per row")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	if err := b.load(env); err != nil {
+		return err
+	}
+	if b.workers < 1 {
+		return errors.New("-workers must be at least 1")
+	}
+	if b.resume && b.out == "" {
+		return errors.New("-resume needs -out")
+	}
+	if b.query == "" && b.structuredColumns() == nil {
+		return errors.New("-query or the columns of a structured query, such as -street and -city, are needed")
+	}
+	format, err := b.format()
+	if err != nil {
+		return err
+	}
+	providers, err := b.batchProviders()
+	if err != nil {
+		return err
+	}
+
+	in := stdin
+	if b.in != "" {
+		f, err := os.Open(b.in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	done, out, err := b.openOutput(format, stdout)
+	if err != nil {
+		return err
+	}
+	if c, ok := out.(io.Closer); ok && out != stdout {
+		defer c.Close()
+	}
+
+	r, err := newRowReader(format, in)
+	if err != nil {
+		return err
+	}
+	if err := b.checkColumns(r); err != nil {
+		return err
+	}
+	w := newRowWriter(format, out, r, done == 0)
+
+	failed := false
+	err = b.process(r, w, done, providers, func(index int, err error) {
+		failed = true
+		fmt.Fprintf(stderr, "row %d: %v\n", index+1, err)
+	})
+	if err == nil && failed {
+		err = errFailed
+	}
+	return err
+}
+
+// format returns the format of the input and output
+func (b batchOptions) format() (string, error) {
+	format := b.inputFormat
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(b.in)) {
+		case ".jsonl", ".ndjson", ".json":
+			format = "jsonl"
+		default:
+			format = "csv"
+		}
+	}
+	if format != "csv" && format != "jsonl" {
+		return "", fmt.Errorf("unknown input format %q, expected csv or jsonl", format)
+	}
+	return format, nil
+}
+
+// structuredColumns returns the columns of a structured query by field, or nil for a free-form query
+func (b batchOptions) structuredColumns() map[address.Field]string {
+	var columns map[address.Field]string
+	for field, column := range b.structured {
+		if *column != "" {
+			if columns == nil {
+				columns = map[address.Field]string{}
+			}
+			columns[field] = *column
+		}
+	}
+	return columns
+}
+
+// batchProviders returns the providers, each with its own cache if results are cached,
+// since the cached geocoder keys its results by query only
+func (b batchOptions) batchProviders() ([]namedGeocoder, error) {
+	providers, err := b.providers()
+	if err != nil {
+		return nil, err
+	}
+	b.cacheFile = ""
+	ttl, ok, err := b.cacheTTL()
+	if err != nil || !ok {
+		return providers, err
+	}
+	for i := range providers {
+		providers[i].Geocoder = cached.Geocoder(providers[i].Geocoder, cache.New(ttl, 0))
+	}
+	return providers, nil
+}
+
+// openOutput returns the number of rows already in -out when resuming, and the writer of the output
+func (b batchOptions) openOutput(format string, stdout io.Writer) (int, io.Writer, error) {
+	if b.out == "" {
+		return 0, stdout, nil
+	}
+	if !b.resume {
+		f, err := os.Create(b.out)
+		return 0, f, err
+	}
+	done, err := rowsDone(b.out, format)
+	if err != nil {
+		return 0, nil, err
+	}
+	f, err := os.OpenFile(b.out, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	return done, f, err
+}
+
+// rowsDone returns the number of rows in the output file at path, or 0 if it does not exist,
+// cutting off the last row if it was not written in full
+func rowsDone(path, format string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if complete := bytes.LastIndexByte(data, '\n') + 1; complete < len(data) {
+		data = data[:complete]
+		if err := os.Truncate(path, int64(complete)); err != nil {
+			return 0, err
+		}
+	}
+	if format == "jsonl" {
+		n := 0
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) > 0 {
+				n++
+			}
+		}
+		return n, nil
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return 0, fmt.Errorf("resuming %s: %v", path, err)
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+	// the first record is the header
+	return len(records) - 1, nil
+}
+
+// checkColumns returns an error if a column of the query is not in the header of a CSV input
+func (b batchOptions) checkColumns(r rowReader) error {
+	header := r.header()
+	if header == nil {
+		return nil
+	}
+	columns := map[string]bool{}
+	for _, c := range header {
+		columns[c] = true
+	}
+	var wanted []string
+	if b.query != "" {
+		wanted = strings.Split(b.query, ",")
+	}
+	for _, c := range b.structuredColumns() {
+		wanted = append(wanted, c)
+	}
+	for _, c := range wanted {
+		if !columns[strings.TrimSpace(c)] {
+			return fmt.Errorf("no column %q in the input", strings.TrimSpace(c))
+		}
+	}
+	return nil
+}
+
+// queryOf returns the query of r, and the address it stands for to compare with the address found
+func (b batchOptions) queryOf(r *row) (string, geo.Address) {
+	if b.query != "" {
+		var parts []string
+		for _, c := range strings.Split(b.query, ",") {
+			if v := strings.TrimSpace(r.values[strings.TrimSpace(c)]); v != "" {
+				parts = append(parts, v)
+			}
+		}
+		query := strings.Join(parts, ", ")
+		if p, err := address.Parse(query); err == nil {
+			return query, p.Address
+		}
+		return query, geo.Address{FormattedAddress: query}
+	}
+
+	var a geo.Address
+	for field, column := range b.structuredColumns() {
+		v := strings.TrimSpace(r.values[column])
+		switch field {
+		case address.HouseNumber:
+			a.HouseNumber = v
+		case address.Street:
+			a.Street = v
+		case address.Suburb:
+			a.Suburb = v
+		case address.City:
+			a.City = v
+		case address.Postcode:
+			a.Postcode = v
+		case address.State:
+			a.State = v
+		case address.Country:
+			a.Country = v
+		}
+	}
+	if c, ok := geo.LookupCountry(a.Country); ok {
+		a.CountryCode = c.Alpha2
+	}
+	a.FormattedAddress = address.FormatLine(a)
+	return a.FormattedAddress, a
+}
+
+// geocodeRow geocodes the query of r with each provider in turn until one finds it, taking the formatted address
+// and the confidence of the match from the address found with the location, or with -reverse from the address
+// the provider reverse geocodes the location to
+func (b batchOptions) geocodeRow(r *row, providers []namedGeocoder) outcome {
+	query, expected := b.queryOf(r)
+	if query == "" {
+		return outcome{err: errors.New("empty query")}
+	}
+	var last error
+	for _, p := range providers {
+		res, err := geo.GeocodeResult(p.Geocoder, query)
+		if err != nil {
+			last = err
+			continue
+		}
+		if res == nil {
+			continue
+		}
+		o := outcome{location: &res.Location, provider: p.name}
+		a := res.Address
+		if b.reverse {
+			if reversed, err := p.ReverseGeocode(res.Location.Lat, res.Location.Lng); err == nil && reversed != nil {
+				a = reversed
+			}
+		}
+		if a != nil {
+			confidence := address.Similarity(expected, matched(components(*a), expected))
+			o.formatted, o.confidence = a.FormattedAddress, &confidence
+		}
+		return o
+	}
+	return outcome{err: last}
+}
+
+// components returns a with the components parsed from its formatted address if it has none,
+// as services often describe the place found by a name alone
+func components(a geo.Address) geo.Address {
+	if a.HouseNumber != "" || a.Street != "" || a.Postcode != "" || a.City != "" {
+		return a
+	}
+	if p, err := address.ParseCountry(a.FormattedAddress, a.CountryCode); err == nil {
+		return p.Address
+	}
+	return a
+}
+
+// matched returns the components of found the query of expected has, so that those the provider adds,
+// such as the postcode of an address without one, do not lower the confidence
+func matched(found, expected geo.Address) geo.Address {
+	if expected.HouseNumber == "" {
+		found.HouseNumber = ""
+	}
+	if expected.Street == "" {
+		found.Street = ""
+	}
+	if expected.Postcode == "" {
+		found.Postcode = ""
+	}
+	if expected.City == "" {
+		found.City = ""
+	}
+	return found
+}
+
+// process geocodes the rows of r after the first skip with workers at the same time, writing them to w in order,
+// and reporting the rows which failed
+func (b batchOptions) process(r rowReader, w rowWriter, skip int, providers []namedGeocoder, report func(int, error)) error {
+	type result struct {
+		row     *row
+		outcome outcome
+	}
+	rows, results := make(chan *row), make(chan result)
+	var readErr error
+	go func() {
+		defer close(rows)
+		for {
+			row, err := r.next()
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+			if row.index >= skip {
+				rows <- row
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < b.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range rows {
+				results <- result{row: row, outcome: b.geocodeRow(row, providers)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// rows are written in the order they were read, keeping those done early until their turn
+	pending, next := map[int]result{}, skip
+	var writeErr error
+	for res := range results {
+		pending[res.row.index] = res
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if res.outcome.err != nil {
+				report(res.row.index, res.outcome.err)
+			}
+			if writeErr == nil {
+				writeErr = w.write(res.row, res.outcome)
+			}
+		}
+	}
+	if readErr != nil {
+		return readErr
+	}
+	return writeErr
+}
+
+// rowReader reads the rows of the input
+type rowReader interface {
+	// header returns the columns of a CSV input, or nil for JSON Lines
+	header() []string
+	// next returns the next row, or io.EOF after the last one
+	next() (*row, error)
+}
+
+func newRowReader(format string, in io.Reader) (rowReader, error) {
+	if format == "jsonl" {
+		d := json.NewDecoder(in)
+		d.UseNumber()
+		return &jsonLinesReader{d: d}, nil
+	}
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty input, expected a CSV header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &csvReader{r: r, columns: header}, nil
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns []string
+	index   int
+}
+
+func (c *csvReader) header() []string { return c.columns }
+
+func (c *csvReader) next() (*row, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	r := &row{index: c.index, record: record, values: map[string]string{}}
+	c.index++
+	for i, column := range c.columns {
+		if i < len(record) {
+			r.values[column] = record[i]
+		}
+	}
+	return r, nil
+}
+
+type jsonLinesReader struct {
+	d     *json.Decoder
+	index int
+}
+
+func (*jsonLinesReader) header() []string { return nil }
+
+func (j *jsonLinesReader) next() (*row, error) {
+	var object map[string]interface{}
+	if err := j.d.Decode(&object); err != nil {
+		if err != io.EOF {
+			err = fmt.Errorf("row %d: %v", j.index+1, err)
+		}
+		return nil, err
+	}
+	r := &row{index: j.index, object: object, values: map[string]string{}}
+	j.index++
+	for k, v := range object {
+		if s, ok := v.(string); ok {
+			r.values[k] = s
+		} else if v != nil {
+			r.values[k] = fmt.Sprint(v)
+		}
+	}
+	return r, nil
+}
+
+// rowWriter writes each row of the input with its outcome
+type rowWriter interface {
+	write(*row, outcome) error
+}
+
+func newRowWriter(format string, out io.Writer, r rowReader, withHeader bool) rowWriter {
+	if format == "jsonl" {
+		return jsonLinesWriter{e: json.NewEncoder(out)}
+	}
+	return &csvWriter{w: csv.NewWriter(out), header: append(append([]string(nil), r.header()...), outputColumns...), withHeader: withHeader}
+}
+
+type csvWriter struct {
+	w          *csv.Writer
+	header     []string
+	withHeader bool
+}
+
+// formatFloat returns f with precision decimals, or "" if it is nil
+func formatFloat(f *float64, precision int) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', precision, 64)
+}
+
+func (c *csvWriter) write(r *row, o outcome) error {
+	if c.withHeader {
+		c.withHeader = false
+		if err := c.w.Write(c.header); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(c.header)-len(outputColumns), len(c.header))
+	copy(record, r.record)
+	var lat, lng *float64
+	if o.location != nil {
+		lat, lng = &o.location.Lat, &o.location.Lng
+	}
+	record = append(record, formatFloat(lat, 6), formatFloat(lng, 6), o.formatted, o.provider, formatFloat(o.confidence, 2))
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	// each row is flushed for -resume to find it after an interruption
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonLinesWriter struct{ e *json.Encoder }
+
+func (j jsonLinesWriter) write(r *row, o outcome) error {
+	object := map[string]interface{}{}
+	for k, v := range r.object {
+		object[k] = v
+	}
+	object["lat"], object["lng"], object["confidence"] = nil, nil, nil
+	if o.location != nil {
+		object["lat"], object["lng"] = o.location.Lat, o.location.Lng
+	}
+	if o.confidence != nil {
+		object["confidence"] = math.Round(*o.confidence*100) / 100
+	}
+	object["formatted_address"], object["provider"] = o.formatted, o.provider
+	return j.e.Encode(object)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const addresses = `id,street,city,country
+1,Postal Lane,Melbourne,Australia
+2,Nowhere Road,Atlantis,
+3,Elizabeth Street,Melbourne,Australia
+`
+
+func TestBatchCSV(t *testing.T) {
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	// Atlantis is not found, which is not a failure
+	out, err := runGeo(t, noEnv, addresses, "batch", "-config", os.DevNull, "-url", ts.URL+"/", "-query", "street,city,country")
+	assert.NoError(t, err)
+	assert.Equal(t, `id,street,city,country,lat,lng,formatted_address,provider,confidence
+1,Postal Lane,Melbourne,Australia,-37.814218,144.963161,"Melbourne, Victoria, Australia",osm,0.53
+2,Nowhere Road,Atlantis,,,,,,
+3,Elizabeth Street,Melbourne,Australia,-37.814218,144.963161,"Melbourne, Victoria, Australia",osm,0.53
+`, out)
+	assert.Equal(t, 3, requests)
+
+	// the address of the location, with one more request per row found
+	requests = 0
+	out, err = runGeo(t, noEnv, addresses, "batch", "-config", os.DevNull, "-url", ts.URL+"/", "-query", "street,city,country", "-reverse")
+	assert.NoError(t, err)
+	assert.Equal(t, `id,street,city,country,lat,lng,formatted_address,provider,confidence
+1,Postal Lane,Melbourne,Australia,-37.814218,144.963161,"Postal Lane, Melbourne, Victoria, 3000, Australia",osm,1.00
+2,Nowhere Road,Atlantis,,,,,,
+3,Elizabeth Street,Melbourne,Australia,-37.814218,144.963161,"Postal Lane, Melbourne, Victoria, 3000, Australia",osm,0.48
+`, out)
+	assert.Equal(t, 5, requests)
+
+	_, err = runGeo(t, noEnv, addresses, "batch", "-config", os.DevNull, "-url", ts.URL+"/", "-query", "street,town")
+	assert.EqualError(t, err, `no column "town" in the input`)
+
+	out, err = runGeo(t, noEnv, "street\nError Street\nPostal Lane Melbourne\n", "batch", "-config", os.DevNull, "-url", ts.URL+"/", "-query", "street", "-reverse")
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, `street,lat,lng,formatted_address,provider,confidence
+Error Street,,,,,
+Postal Lane Melbourne,-37.814218,144.963161,"Postal Lane, Melbourne, Victoria, 3000, Australia",osm,0.52
+`, out)
+}
+
+func TestBatchJSONLines(t *testing.T) {
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	in := `{"id": 1, "street": "Postal Lane", "city": "Melbourne", "country": "Australia"}
+{"id": 2, "street": "Nowhere Road", "city": "Atlantis"}
+`
+	out, err := runGeo(t, noEnv, in, "batch", "-config", os.DevNull, "-url", ts.URL+"/", "-input-format", "jsonl",
+		"-street", "street", "-city", "city", "-country", "country", "-workers", "1", "-reverse")
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 2)
+	var first, second map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, 1.0, first["id"])
+	assert.Equal(t, -37.8142176, first["lat"])
+	assert.Equal(t, "osm", first["provider"])
+	assert.Equal(t, 1.0, first["confidence"])
+	assert.Equal(t, "Atlantis", second["city"])
+	assert.Nil(t, second["lat"])
+}
+
+func TestBatchResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	in, out := filepath.Join(dir, "addresses.csv"), filepath.Join(dir, "places.csv")
+	assert.NoError(t, ioutil.WriteFile(in, []byte(addresses), 0644))
+	// the first row was written before an interruption in the middle of the second
+	assert.NoError(t, ioutil.WriteFile(out, []byte(`id,street,city,country,lat,lng,formatted_address,provider,confidence
+1,Postal Lane,Melbourne,Australia,-37.814218,144.963161,"Postal Lane, Melbourne, Victoria, 3000, Australia",osm,1.00
+2,Nowhere Ro`), 0644))
+
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	_, err = runGeo(t, noEnv, "", "batch", "-config", os.DevNull, "-url", ts.URL+"/", "-query", "street,city,country",
+		"-in", in, "-out", out, "-resume", "-reverse")
+	assert.NoError(t, err)
+	// rows 2 and 3 are geocoded, and row 3 reverse geocoded
+	assert.Equal(t, 3, requests)
+
+	data, err := ioutil.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, `id,street,city,country,lat,lng,formatted_address,provider,confidence
+1,Postal Lane,Melbourne,Australia,-37.814218,144.963161,"Postal Lane, Melbourne, Victoria, 3000, Australia",osm,1.00
+2,Nowhere Road,Atlantis,,,,,,
+3,Elizabeth Street,Melbourne,Australia,-37.814218,144.963161,"Postal Lane, Melbourne, Victoria, 3000, Australia",osm,0.48
+`, string(data))
+
+	_, err = runGeo(t, noEnv, "", "batch", "-config", os.DevNull, "-query", "street", "-resume")
+	assert.EqualError(t, err, "-resume needs -out")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/gob"
+	"os"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/patrickmn/go-cache"
+)
+
+func init() {
+	// the cached geocoder keeps pointers to results in the cache
+	gob.Register(&geo.Location{})
+	gob.Register(&geo.Result{})
+	gob.Register(&geo.Address{})
+}
+
+// openCache returns a cache of results expiring after ttl, with those saved in path if it is not empty
+func openCache(path string, ttl time.Duration) (*cache.Cache, error) {
+	c := cache.New(ttl, 0)
+	if path == "" {
+		return c, nil
+	}
+	if err := c.LoadFile(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return c, nil
+}
+
+// saveCache saves the results in c to path if it is not empty, leaving out the queries not found,
+// which gob cannot encode
+func saveCache(path string, c *cache.Cache, ttl time.Duration) error {
+	if path == "" {
+		return nil
+	}
+	items := map[string]cache.Item{}
+	for k, item := range c.Items() {
+		switch v := item.Object.(type) {
+		case *geo.Location:
+			if v == nil {
+				continue
+			}
+		case *geo.Result:
+			if v == nil {
+				continue
+			}
+		case *geo.Address:
+			if v == nil {
+				continue
+			}
+		}
+		items[k] = item
+	}
+	return cache.NewFrom(ttl, 0, items).SaveFile(path)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// configFile is the name of the config file in the home directory, unless set by GEO_CONFIG or -config
+const configFile = ".geo.json"
+
+// config is the content of the config file, giving the defaults of the flags and the keys of providers by name,
+// such as {"provider": "osm,google", "keys": {"GOOGLE_API_KEY": "..."}}
+type config struct {
+	Provider  string            `json:"provider"`
+	URL       string            `json:"url"`
+	Format    string            `json:"format"`
+	Cache     string            `json:"cache"`
+	CacheFile string            `json:"cacheFile"`
+	Rate      float64           `json:"rate"`
+	Keys      map[string]string `json:"keys"`
+}
+
+// configPath returns the path of the config file
+func configPath(env func(string) string) string {
+	if path := env("GEO_CONFIG"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, configFile)
+}
+
+// loadConfig returns the config in path, or an empty config if path is empty,
+// or if it is the default path and does not exist
+func loadConfig(path string, explicit bool) (config, error) {
+	var c config
+	if path == "" {
+		return c, nil
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) && !explicit {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	defer f.Close()
+	// an empty file is an empty config
+	if err := json.NewDecoder(f).Decode(&c); err != nil && err != io.EOF {
+		return c, fmt.Errorf("config %s: %v", path, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,314 @@
+// Command geo geocodes addresses and reverse geocodes locations from the shell, with any of the providers
+// of geo-golang, alone or chained, printing the results as text, JSON lines or GeoJSON.
+//
+//	geo geocode -provider osm,google "Melbourne VIC" "Champs de Mars Paris"
+//	geo reverse -provider google -format json -- "-37.813611,144.963056"
+//	geo geocode -format geojson < addresses.txt > places.geojson
+//	geo batch -provider google,osm -rate 10 -query street,city -in addresses.csv -out places.csv -resume
+//
+// Queries are the arguments, or the lines of the standard input if there are none.
+// The keys of providers are looked up on the command line, as -key GOOGLE_API_KEY=..., then in the environment,
+// then in the config file ~/.geo.json, or the one in GEO_CONFIG or -config, which can also set the defaults of flags:
+//
+//	{"provider": "osm,google", "format": "json", "cache": "1h", "keys": {"GOOGLE_API_KEY": "..."}}
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/cached"
+	"github.com/codingsince1985/geo-golang/chained"
+	"github.com/codingsince1985/geo-golang/cmd/internal/providers"
+	"github.com/codingsince1985/geo-golang/ratelimited"
+	"github.com/patrickmn/go-cache"
+)
+
+// errFailed occurs when geocoding some queries failed, after reporting each of them
+var errFailed = errors.New("some queries failed")
+
+const usage = `usage: geo <command> [flags] [queries]
+
+commands:
+  geocode    geocode addresses to locations
+  reverse    reverse geocode locations, in any notation of geo.ParseCoordinates, to addresses
+  batch      geocode the rows of a CSV or JSON Lines file
+  providers  list the providers and the keys they need
+
+Run geo <command> -h for the flags of a command.
+`
+
+func main() {
+	err := run(os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr)
+	if err == flag.ErrHelp {
+		os.Exit(2)
+	}
+	if err != nil {
+		if err != errFailed {
+			fmt.Fprintln(os.Stderr, "geo:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// options are the flags common to the commands
+type options struct {
+	provider, url, format, cache, cacheFile string
+	rate                                    float64
+	keys                                    providers.KeyFlags
+	configFile                              string
+	lookup                                  func(string) string
+}
+
+// newFlagSet returns the flags of command, with those common to the commands set in o
+func newFlagSet(command string, o *options, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("geo "+command, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	o.keys = providers.KeyFlags{}
+	fs.StringVar(&o.configFile, "config", "", "config `file`, instead of GEO_CONFIG or ~/"+configFile)
+	fs.StringVar(&o.provider, "provider", "", "`names` of the providers, separated by commas to fall back on the next (default osm)")
+	fs.StringVar(&o.url, "url", "", "base `URL` replacing that of a single provider, such as a self-hosted Nominatim")
+	fs.StringVar(&o.cache, "cache", "", "cache results for `duration`, such as 1h")
+	fs.Float64Var(&o.rate, "rate", 0, "at most `n` requests a second to each provider")
+	fs.Var(o.keys, "key", "key of a provider as `NAME=value`, such as GOOGLE_API_KEY=...; repeatable")
+	return fs
+}
+
+// load sets the options not given as flags from the config file, and how to look up keys
+func (o *options) load(env func(string) string) error {
+	path, explicit := o.configFile, o.configFile != ""
+	if !explicit {
+		path = configPath(env)
+		explicit = env("GEO_CONFIG") != ""
+	}
+	c, err := loadConfig(path, explicit)
+	if err != nil {
+		return err
+	}
+	o.applyDefaults(c)
+	o.lookup = providers.Lookup(o.keys, env, c.Keys)
+	return nil
+}
+
+// run runs the command of args, reading queries from stdin if args has none
+func run(args []string, env func(string) string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	}
+	switch args[0] {
+	case "geocode", "reverse":
+	case "batch":
+		return runBatch(args[1:], env, stdin, stdout, stderr)
+	case "providers":
+		return listProviders(stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	command := args[0]
+	var o options
+	fs := newFlagSet(command, &o, stderr)
+	fs.StringVar(&o.format, "format", "", "output `format`: text, json or geojson (default text)")
+	fs.StringVar(&o.cacheFile, "cache-file", "", "`file` keeping the cache between runs")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if err := o.load(env); err != nil {
+		return err
+	}
+
+	newWriter, ok := formats[o.format]
+	if !ok {
+		return fmt.Errorf("unknown format %q, expected text, json or geojson", o.format)
+	}
+	geocoder, done, err := o.geocoder()
+	if err != nil {
+		return err
+	}
+
+	w := newWriter(stdout)
+	failed := false
+	err = eachQuery(fs.Args(), stdin, func(query string) error {
+		if command == "geocode" {
+			r, err := geocode(geocoder, query)
+			failed = failed || err != nil
+			return w.geocoded(query, r, err)
+		}
+		l, a, err := reverse(geocoder, query)
+		failed = failed || err != nil
+		return w.reversed(query, l, a, err)
+	})
+	if err == nil {
+		err = w.close()
+	}
+	if cacheErr := done(); err == nil {
+		err = cacheErr
+	}
+	if err == nil && failed {
+		err = errFailed
+	}
+	return err
+}
+
+// applyDefaults sets the options not given as flags from c, or to their defaults
+func (o *options) applyDefaults(c config) {
+	for _, d := range []struct {
+		option           *string
+		config, fallback string
+	}{
+		{&o.provider, c.Provider, "osm"},
+		{&o.url, c.URL, ""},
+		{&o.format, c.Format, "text"},
+		{&o.cache, c.Cache, ""},
+		{&o.cacheFile, c.CacheFile, ""},
+	} {
+		if *d.option == "" {
+			*d.option = d.config
+		}
+		if *d.option == "" {
+			*d.option = d.fallback
+		}
+	}
+	if o.rate == 0 {
+		o.rate = c.Rate
+	}
+}
+
+// namedGeocoder is the geocoder of a provider
+type namedGeocoder struct {
+	name string
+	geo.Geocoder
+}
+
+// providers returns the geocoders of the providers of the options in order, rate limited if need be
+func (o options) providers() ([]namedGeocoder, error) {
+	names := strings.Split(o.provider, ",")
+	if o.url != "" && len(names) > 1 {
+		return nil, errors.New("-url needs a single provider")
+	}
+	var baseURLs []string
+	if o.url != "" {
+		baseURLs = []string{o.url}
+	}
+	var geocoders []namedGeocoder
+	for _, name := range names {
+		g, err := providers.New(name, o.lookup, baseURLs...)
+		if err != nil {
+			return nil, err
+		}
+		if o.rate > 0 {
+			g = ratelimited.Geocoder(g, o.rate)
+		}
+		geocoders = append(geocoders, namedGeocoder{name: strings.TrimSpace(name), Geocoder: g})
+	}
+	return geocoders, nil
+}
+
+// cacheTTL returns how long results are cached, or false if they are not
+func (o options) cacheTTL() (time.Duration, bool, error) {
+	if o.cache == "" && o.cacheFile == "" {
+		return 0, false, nil
+	}
+	if o.cache == "" {
+		return cache.NoExpiration, true, nil
+	}
+	d, err := time.ParseDuration(o.cache)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid -cache: %v", err)
+	}
+	return d, true, nil
+}
+
+// geocoder returns the geocoder of the options, and the function to call once done with it
+func (o options) geocoder() (geo.Geocoder, func() error, error) {
+	providers, err := o.providers()
+	if err != nil {
+		return nil, nil, err
+	}
+	geocoder := providers[0].Geocoder
+	if len(providers) > 1 {
+		geocoders := make([]geo.Geocoder, len(providers))
+		for i, p := range providers {
+			geocoders[i] = p.Geocoder
+		}
+		geocoder = chained.Geocoder(geocoders...)
+	}
+
+	ttl, ok, err := o.cacheTTL()
+	if err != nil || !ok {
+		return geocoder, func() error { return nil }, err
+	}
+	c, err := openCache(o.cacheFile, ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cached.Geocoder(geocoder, c), func() error { return saveCache(o.cacheFile, c, ttl) }, nil
+}
+
+// eachQuery calls f with each of args, or each non blank line of stdin if there are none
+func eachQuery(args []string, stdin io.Reader, f func(string) error) error {
+	if len(args) > 0 {
+		for _, query := range args {
+			if err := f(query); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		if query := strings.TrimSpace(scanner.Text()); query != "" {
+			if err := f(query); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// geocode returns the result of query, with its bounding box and timezone if geocoder supplies them
+func geocode(geocoder geo.Geocoder, query string) (*geo.Result, error) {
+	if r, ok := geocoder.(geo.ResultGeocoder); ok {
+		return r.GeocodeResult(query)
+	}
+	l, err := geocoder.Geocode(query)
+	if l == nil {
+		return nil, err
+	}
+	return &geo.Result{Location: *l}, err
+}
+
+// reverse returns the address of the location in query
+func reverse(geocoder geo.Geocoder, query string) (geo.Location, *geo.Address, error) {
+	l, err := geo.ParseCoordinates(query)
+	if err != nil {
+		return l, nil, fmt.Errorf("%q: %v", query, err)
+	}
+	a, err := geocoder.ReverseGeocode(l.Lat, l.Lng)
+	return l, a, err
+}
+
+// listProviders writes the name of each provider with the keys it needs
+func listProviders(w io.Writer) error {
+	for _, name := range providers.Names() {
+		keys := providers.Keys(name)
+		sort.Strings(keys)
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", name, strings.Join(keys, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
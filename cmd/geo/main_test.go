@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang/cmd/internal/providers"
+	"github.com/codingsince1985/geo-golang/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+// nominatimServer answers searches for Melbourne and reverse geocoding near it, fails searches for Error,
+// and finds nothing else
+func nominatimServer(requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		*requests++
+		switch {
+		case strings.Contains(req.URL.Query().Get("q"), "Error"):
+			resp.Write([]byte(`{"error": "Unable to geocode"}`))
+		case strings.HasSuffix(req.URL.Path, "/search") && strings.Contains(req.URL.Query().Get("q"), "Melbourne"):
+			resp.Write([]byte(`[{"lat": "-37.8142176", "lon": "144.9631608", "display_name": "Melbourne, Victoria, Australia",
+				"boundingbox": ["-37.8", "-37.7", "144.8", "144.9"]}]`))
+		case strings.HasSuffix(req.URL.Path, "/reverse"):
+			resp.Write([]byte(`{"lat": "-37.8137", "lon": "144.9631", "display_name": "Postal Lane, Melbourne, Victoria, 3000, Australia",
+				"address": {"road": "Postal Lane", "city": "Melbourne", "state": "Victoria", "postcode": "3000",
+				"country": "Australia", "country_code": "au"}}`))
+		default:
+			resp.Write([]byte(`[]`))
+		}
+	}))
+}
+
+func noEnv(string) string { return "" }
+
+func runGeo(t *testing.T, env func(string) string, stdin string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := run(args, env, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestGeocodeText(t *testing.T) {
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	out, err := runGeo(t, noEnv, "", "geocode", "-config", os.DevNull, "-url", ts.URL+"/", "Melbourne VIC", "Atlantis")
+	assert.NoError(t, err)
+	assert.Equal(t, "Melbourne VIC\t-37.814218,144.963161\nAtlantis\tnot found\n", out)
+
+	// queries are read from stdin without arguments
+	out, err = runGeo(t, noEnv, "Melbourne VIC\n\n", "geocode", "-config", os.DevNull, "-url", ts.URL+"/")
+	assert.NoError(t, err)
+	assert.Equal(t, "Melbourne VIC\t-37.814218,144.963161\n", out)
+}
+
+func TestGeocodeJSON(t *testing.T) {
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	out, err := runGeo(t, noEnv, "", "geocode", "-config", os.DevNull, "-url", ts.URL+"/", "-format", "json", "Melbourne VIC")
+	assert.NoError(t, err)
+	var line jsonLine
+	assert.NoError(t, json.Unmarshal([]byte(out), &line))
+	assert.Equal(t, "Melbourne VIC", line.Query)
+	assert.InDelta(t, -37.8142176, line.Location.Lat, 1e-9)
+	assert.NotNil(t, line.BoundingBox)
+}
+
+func TestReverseGeoJSON(t *testing.T) {
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	out, err := runGeo(t, noEnv, "", "reverse", "-config", os.DevNull, "-url", ts.URL+"/", "-format", "geojson", "--",
+		"-37.813611, 144.963056", `37°48'49"S 144°57'47"E`)
+	assert.NoError(t, err)
+	features, err := feature.ParseGeoJSON([]byte(out))
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Equal(t, "Postal Lane", features[0].Address.Street)
+	assert.Equal(t, "AU", features[0].Address.CountryCode)
+
+	_, err = runGeo(t, noEnv, "", "reverse", "-config", os.DevNull, "-url", ts.URL+"/", "nowhere")
+	assert.Equal(t, errFailed, err)
+}
+
+func TestKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"provider": "google", "keys": {"GOOGLE_API_KEY": "from config", "BING_API_KEY": "bing"}}`), 0600))
+
+	c, err := loadConfig(path, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "google", c.Provider)
+	_, err = loadConfig(filepath.Join(dir, "missing.json"), true)
+	assert.Error(t, err)
+	_, err = loadConfig(filepath.Join(dir, "missing.json"), false)
+	assert.NoError(t, err)
+
+	env := func(name string) string {
+		if name == "GOOGLE_API_KEY" {
+			return "from env"
+		}
+		return ""
+	}
+	assert.Equal(t, "from config", providers.Lookup(providers.KeyFlags{}, noEnv, c.Keys)("GOOGLE_API_KEY"))
+	assert.Equal(t, "from env", providers.Lookup(providers.KeyFlags{}, env, c.Keys)("GOOGLE_API_KEY"))
+	assert.Equal(t, "from flag", providers.Lookup(providers.KeyFlags{"GOOGLE_API_KEY": "from flag"}, env, c.Keys)("GOOGLE_API_KEY"))
+	assert.Equal(t, "bing", providers.Lookup(providers.KeyFlags{}, env, c.Keys)("BING_API_KEY"))
+
+	_, err = runGeo(t, noEnv, "", "geocode", "-config", os.DevNull, "-provider", "google", "Melbourne")
+	assert.Error(t, err)
+	_, err = runGeo(t, noEnv, "", "geocode", "-config", os.DevNull, "-provider", "atlantis", "Melbourne")
+	assert.Error(t, err)
+	_, err = runGeo(t, noEnv, "", "geocode", "-config", os.DevNull, "-key", "GOOGLE_API_KEY", "Melbourne")
+	assert.Error(t, err)
+}
+
+func TestCacheFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cacheFile := filepath.Join(dir, "cache.gob")
+
+	requests := 0
+	ts := nominatimServer(&requests)
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		out, err := runGeo(t, noEnv, "", "geocode", "-config", os.DevNull, "-url", ts.URL+"/", "-cache-file", cacheFile,
+			"Melbourne VIC", "Melbourne VIC", "Atlantis")
+		assert.NoError(t, err)
+		assert.Equal(t, "Melbourne VIC\t-37.814218,144.963161\nMelbourne VIC\t-37.814218,144.963161\nAtlantis\tnot found\n", out)
+	}
+	// Melbourne is cached for the second run, unlike Atlantis which was not found
+	assert.Equal(t, 3, requests)
+}
+
+func TestProviders(t *testing.T) {
+	out, err := runGeo(t, noEnv, "", "providers")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "google\tGOOGLE_API_KEY\n")
+	assert.Contains(t, out, "osm\t\n")
+}
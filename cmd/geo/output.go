@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/feature"
+)
+
+// formats are the output formats by name
+var formats = map[string]func(io.Writer) writer{
+	"text":    func(w io.Writer) writer { return textWriter{w} },
+	"json":    func(w io.Writer) writer { return jsonWriter{json.NewEncoder(w)} },
+	"geojson": func(w io.Writer) writer { return &geoJSONWriter{w: w} },
+}
+
+// writer writes the outcome of each query, then ends the output with close
+type writer interface {
+	geocoded(query string, r *geo.Result, err error) error
+	reversed(query string, l geo.Location, a *geo.Address, err error) error
+	close() error
+}
+
+// textWriter writes a line per query, with the query and its outcome separated by a tab
+type textWriter struct{ w io.Writer }
+
+func (t textWriter) geocoded(query string, r *geo.Result, err error) error {
+	switch {
+	case err != nil:
+		_, err = fmt.Fprintf(t.w, "%s\terror: %v\n", query, err)
+	case r == nil:
+		_, err = fmt.Fprintf(t.w, "%s\tnot found\n", query)
+	default:
+		_, err = fmt.Fprintf(t.w, "%s\t%.6f,%.6f\n", query, r.Location.Lat, r.Location.Lng)
+	}
+	return err
+}
+
+func (t textWriter) reversed(query string, _ geo.Location, a *geo.Address, err error) error {
+	switch {
+	case err != nil:
+		_, err = fmt.Fprintf(t.w, "%s\terror: %v\n", query, err)
+	case a == nil:
+		_, err = fmt.Fprintf(t.w, "%s\tnot found\n", query)
+	default:
+		_, err = fmt.Fprintf(t.w, "%s\t%s\n", query, a.FormattedAddress)
+	}
+	return err
+}
+
+func (textWriter) close() error { return nil }
+
+// jsonWriter writes a JSON object per line for each query
+type jsonWriter struct{ e *json.Encoder }
+
+type jsonLine struct {
+	Query       string           `json:"query"`
+	Location    *geo.Location    `json:"location,omitempty"`
+	BoundingBox *geo.BoundingBox `json:"boundingBox,omitempty"`
+	Timezone    string           `json:"timezone,omitempty"`
+	Address     *geo.Address     `json:"address,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (j jsonWriter) geocoded(query string, r *geo.Result, err error) error {
+	line := jsonLine{Query: query, Error: errorString(err)}
+	if r != nil {
+		line.Location, line.BoundingBox, line.Timezone = &r.Location, r.BoundingBox, r.Timezone
+	}
+	return j.e.Encode(line)
+}
+
+func (j jsonWriter) reversed(query string, l geo.Location, a *geo.Address, err error) error {
+	line := jsonLine{Query: query, Error: errorString(err), Address: a}
+	if a != nil {
+		line.Location = &l
+	}
+	return j.e.Encode(line)
+}
+
+func (jsonWriter) close() error { return nil }
+
+// geoJSONWriter writes a FeatureCollection of the places found once all queries are done,
+// each geocoded place having the query as its formatted address
+type geoJSONWriter struct {
+	w        io.Writer
+	features feature.Collection
+}
+
+func (g *geoJSONWriter) geocoded(query string, r *geo.Result, _ error) error {
+	if r != nil {
+		g.features = append(g.features, feature.FromResult(*r, &geo.Address{FormattedAddress: query}))
+	}
+	return nil
+}
+
+func (g *geoJSONWriter) reversed(_ string, l geo.Location, a *geo.Address, _ error) error {
+	if a != nil {
+		g.features = append(g.features, feature.Feature{Location: l, Address: a})
+	}
+	return nil
+}
+
+func (g *geoJSONWriter) close() error {
+	if g.features == nil {
+		g.features = feature.Collection{}
+	}
+	return json.NewEncoder(g.w).Encode(g.features)
+}
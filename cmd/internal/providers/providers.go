@@ -0,0 +1,124 @@
+// Package providers constructs the geocoders of the providers of geo-golang by name, for the commands
+package providers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+	// the providers register themselves with geo
+	_ "github.com/codingsince1985/geo-golang/arcgis"
+	_ "github.com/codingsince1985/geo-golang/bing"
+	_ "github.com/codingsince1985/geo-golang/frenchapigouv"
+	_ "github.com/codingsince1985/geo-golang/geocod"
+	_ "github.com/codingsince1985/geo-golang/google"
+	_ "github.com/codingsince1985/geo-golang/here"
+	_ "github.com/codingsince1985/geo-golang/locationiq"
+	_ "github.com/codingsince1985/geo-golang/mapbox"
+	_ "github.com/codingsince1985/geo-golang/mapquest/nominatim"
+	_ "github.com/codingsince1985/geo-golang/mapquest/open"
+	_ "github.com/codingsince1985/geo-golang/mapzen"
+	_ "github.com/codingsince1985/geo-golang/opencage"
+	_ "github.com/codingsince1985/geo-golang/openstreetmap"
+	_ "github.com/codingsince1985/geo-golang/pickpoint"
+	_ "github.com/codingsince1985/geo-golang/tomtom"
+	_ "github.com/codingsince1985/geo-golang/yandex"
+)
+
+// credentials are the names of the keys of the providers needing some, for their application ID and key
+var credentials = map[string]struct{ id, key string }{
+	"arcgis":             {key: "ARCGIS_TOKEN"},
+	"bing":               {key: "BING_API_KEY"},
+	"geocodio":           {key: "GEOCOD_API_KEY"},
+	"google":             {key: "GOOGLE_API_KEY"},
+	"here":               {key: "HERE_API_KEY"},
+	"here6":              {id: "HERE_APP_ID", key: "HERE_APP_CODE"},
+	"locationiq":         {key: "LOCATIONIQ_API_KEY"},
+	"mapbox":             {key: "MAPBOX_API_KEY"},
+	"mapquest-nominatim": {key: "MAPQUEST_NOMINATIM_KEY"},
+	"mapquest-open":      {key: "MAPQUEST_OPEN_KEY"},
+	"mapzen":             {key: "MAPZEN_API_KEY"},
+	"opencage":           {key: "OPENCAGE_API_KEY"},
+	"pickpoint":          {key: "PICKPOINT_API_KEY"},
+	"tomtom":             {key: "TOMTOM_API_KEY"},
+	"yandex":             {key: "YANDEX_API_KEY"},
+}
+
+// aliases are other names of providers
+var aliases = map[string]string{
+	"openstreetmap": "osm",
+	"nominatim":     "osm",
+	"geocod":        "geocodio",
+}
+
+// Names returns the names of the providers in order
+func Names() []string { return geo.Providers() }
+
+// Keys returns the names of the keys the provider of name needs
+func Keys(name string) []string {
+	var keys []string
+	for _, k := range []string{credentials[name].id, credentials[name].key} {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// New returns the geocoder of the provider of name, with its keys looked up by key
+// and baseURLs, if any, replacing those of its service
+func New(name string, key func(string) string, baseURLs ...string) (geo.Geocoder, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	var options geo.Options
+	if len(baseURLs) > 0 {
+		options.BaseURL = baseURLs[0]
+	}
+	for _, k := range Keys(name) {
+		if key(k) == "" {
+			return nil, fmt.Errorf("provider %s needs %s, set with -key %s=... or in the environment or config file", name, k, k)
+		}
+	}
+	if c := credentials[name]; c.key != "" {
+		options.Key = key(c.key)
+		if c.id != "" {
+			options.ID = key(c.id)
+		}
+	}
+	return geo.New(name, options)
+}
+
+// KeyFlags are the keys of providers set on the command line as NAME=value, such as GOOGLE_API_KEY=...
+type KeyFlags map[string]string
+
+func (k KeyFlags) String() string {
+	names := make([]string, 0, len(k))
+	for name := range k {
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+func (k KeyFlags) Set(s string) error {
+	i := strings.Index(s, "=")
+	if i <= 0 {
+		return fmt.Errorf("%q is not NAME=value", s)
+	}
+	k[s[:i]] = s[i+1:]
+	return nil
+}
+
+// Lookup returns the function looking up a key in flags, then with env, then in keys
+func Lookup(flags KeyFlags, env func(string) string, keys map[string]string) func(string) string {
+	return func(name string) string {
+		if v, ok := flags[name]; ok {
+			return v
+		}
+		if v := env(name); v != "" {
+			return v
+		}
+		return keys[name]
+	}
+}
@@ -0,0 +1,290 @@
+// Package config builds geocoders from declarative configuration in YAML or JSON: the providers
+// to use and in which order, their keys, base URLs, timeouts, rate limits, caches and retry policies,
+// and how they are chained. Providers are those registered with geo, the ones of geo-golang included
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/cached"
+	"github.com/codingsince1985/geo-golang/chained"
+	"github.com/codingsince1985/geo-golang/ratelimited"
+	"github.com/codingsince1985/geo-golang/retrying"
+	"github.com/patrickmn/go-cache"
+	"gopkg.in/yaml.v2"
+)
+
+// Chain modes, how the providers are chained
+const (
+	// Fallback asks the providers in turn until one finds a result
+	Fallback = "fallback"
+	// Fastest asks all providers at once and answers with the first result found
+	Fastest = "fastest"
+)
+
+// Memory is the cache backend keeping results in memory, the only one so far
+const Memory = "memory"
+
+// Config is the configuration of a geocoder
+type Config struct {
+	// Providers are the geocoding services in the order they are asked
+	Providers []Provider `json:"providers" yaml:"providers"`
+	// Chain is how the providers are chained, Fallback if empty
+	Chain string `json:"chain,omitempty" yaml:"chain,omitempty"`
+	// Timeout is how long a request to a provider can take, unless the provider sets its own
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Retry is the retry policy of providers without their own
+	Retry *Retry `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Cache caches the results of the whole chain
+	Cache *Cache `json:"cache,omitempty" yaml:"cache,omitempty"`
+}
+
+// Provider is the configuration of a geocoding service
+type Provider struct {
+	// Name is the name the provider is registered with geo
+	Name string `json:"name" yaml:"name"`
+	// Key is the key of the service, if it needs one
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// ID is the application ID of services needing one along with Key
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// BaseURL replaces the URL of the service, to use a mirror or a self-hosted instance
+	BaseURL string `json:"baseURL,omitempty" yaml:"baseURL,omitempty"`
+	// Language is the preferred language of addresses
+	Language string `json:"language,omitempty" yaml:"language,omitempty"`
+	// Region is the country code results are biased to or restricted to
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+	// Timeout is how long a request can take
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Rate is the number of requests per second, no limit if zero
+	Rate float64 `json:"rate,omitempty" yaml:"rate,omitempty"`
+	// Retry is the retry policy of the provider
+	Retry *Retry `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Cache caches the results of the provider
+	Cache *Cache `json:"cache,omitempty" yaml:"cache,omitempty"`
+	// Raw keeps the responses of the provider in the sources of results, to reach the fields they do not model
+	Raw bool `json:"raw,omitempty" yaml:"raw,omitempty"`
+	// Options are the settings specific to the provider, such as zoom for locationiq
+	Options map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// Retry is a retry policy
+type Retry struct {
+	// Attempts is the number of tries of a request
+	Attempts int `json:"attempts" yaml:"attempts"`
+	// Backoff is the wait after the first failure, doubled after each of the next ones
+	Backoff Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// MaxBackoff caps the wait between two tries
+	MaxBackoff Duration `json:"maxBackoff,omitempty" yaml:"maxBackoff,omitempty"`
+}
+
+// Cache is the configuration of a cache
+type Cache struct {
+	// Backend is where results are kept, Memory if empty
+	Backend string `json:"backend,omitempty" yaml:"backend,omitempty"`
+	// TTL is how long results are kept, forever if zero
+	TTL Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// CleanupInterval is how often expired results are removed, never if zero
+	CleanupInterval Duration `json:"cleanupInterval,omitempty" yaml:"cleanupInterval,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "1.5s" or "2m"
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string, or a number of nanoseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s interface{}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.set(s)
+}
+
+// UnmarshalYAML parses a duration string, or a number of nanoseconds
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s interface{}
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.set(s)
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) { return json.Marshal(time.Duration(d).String()) }
+
+// MarshalYAML writes the duration as a string
+func (d Duration) MarshalYAML() (interface{}, error) { return time.Duration(d).String(), nil }
+
+func (d *Duration) set(v interface{}) error {
+	switch v := v.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(v)
+	case int:
+		*d = Duration(v)
+	default:
+		return fmt.Errorf("invalid duration %v", v)
+	}
+	return nil
+}
+
+// Load reads the configuration in the file at path, in JSON if its extension is .json, in YAML otherwise
+func Load(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSON(data)
+	}
+	return ParseYAML(data)
+}
+
+// ParseYAML parses configuration in YAML, expanding the environment variables in it
+func ParseYAML(data []byte) (Config, error) {
+	var c Config
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return Config{}, err
+	}
+	if err := c.expand(os.LookupEnv); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// ParseJSON parses configuration in JSON, expanding the environment variables in it
+func ParseJSON(data []byte) (Config, error) {
+	var c Config
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&c); err != nil {
+		return Config{}, err
+	}
+	if err := c.expand(os.LookupEnv); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+var variable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expand replaces ${NAME} in the keys, IDs, base URLs and options of providers with the value of
+// environment variable NAME, failing on those not set so that a missing key is not sent empty
+func (c *Config) expand(lookup func(string) (string, bool)) error {
+	var err error
+	replace := func(s string) string {
+		return variable.ReplaceAllStringFunc(s, func(v string) string {
+			name := variable.FindStringSubmatch(v)[1]
+			value, ok := lookup(name)
+			if !ok && err == nil {
+				err = fmt.Errorf("environment variable %s is not set", name)
+			}
+			return value
+		})
+	}
+	for i := range c.Providers {
+		p := &c.Providers[i]
+		p.Key = replace(p.Key)
+		p.ID = replace(p.ID)
+		p.BaseURL = replace(p.BaseURL)
+		for k, v := range p.Options {
+			p.Options[k] = replace(v)
+		}
+	}
+	return err
+}
+
+// Build returns the geocoder of the configuration: each provider, rate limited, retried and cached
+// as configured, chained with the others, and the chain cached
+func (c Config) Build() (geo.Geocoder, error) {
+	if len(c.Providers) == 0 {
+		return nil, fmt.Errorf("no providers configured")
+	}
+	geocoders := make([]geo.Geocoder, len(c.Providers))
+	for i, p := range c.Providers {
+		g, err := c.provider(p)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %v", p.Name, err)
+		}
+		geocoders[i] = g
+	}
+
+	var g geo.Geocoder
+	switch strings.ToLower(c.Chain) {
+	case "", Fallback:
+		if g = geocoders[0]; len(geocoders) > 1 {
+			g = chained.Geocoder(geocoders...)
+		}
+	case Fastest:
+		g = chained.Fastest(geocoders...)
+	default:
+		return nil, fmt.Errorf("unknown chain %q, expected %s or %s", c.Chain, Fallback, Fastest)
+	}
+	return withCache(g, c.Cache)
+}
+
+// provider builds the geocoder of p with the defaults of c
+func (c Config) provider(p Provider) (geo.Geocoder, error) {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = c.Timeout
+	}
+	g, err := geo.New(p.Name, geo.Options{
+		Key:      p.Key,
+		ID:       p.ID,
+		BaseURL:  p.BaseURL,
+		Language: p.Language,
+		Region:   p.Region,
+		Timeout:  time.Duration(timeout),
+		Raw:      p.Raw,
+		Extra:    p.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p.Rate > 0 {
+		g = ratelimited.Geocoder(g, p.Rate)
+	}
+	retry := p.Retry
+	if retry == nil {
+		retry = c.Retry
+	}
+	if retry != nil {
+		g = retrying.Geocoder(g, retrying.Policy{
+			Attempts:   retry.Attempts,
+			Backoff:    time.Duration(retry.Backoff),
+			MaxBackoff: time.Duration(retry.MaxBackoff),
+		})
+	}
+	return withCache(g, p.Cache)
+}
+
+// withCache caches the results of g as configured, if it is
+func withCache(g geo.Geocoder, c *Cache) (geo.Geocoder, error) {
+	if c == nil {
+		return g, nil
+	}
+	switch strings.ToLower(c.Backend) {
+	case "", Memory:
+		ttl := time.Duration(c.TTL)
+		if ttl == 0 {
+			ttl = cache.NoExpiration
+		}
+		return cached.Geocoder(g, cache.New(ttl, time.Duration(c.CleanupInterval))), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q, expected %s", c.Backend, Memory)
+	}
+}
@@ -0,0 +1,177 @@
+package config_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/config"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addressFixture  = geo.Address{FormattedAddress: "64 Elizabeth Street, Melbourne, Victoria 3000, Australia"}
+	locationFixture = geo.Location{Lat: -37.814107, Lng: 144.96328}
+)
+
+const yamlFixture = `
+providers:
+  - name: google
+    key: ${GEO_TEST_KEY}
+    timeout: 2s
+    rate: 10
+  - name: osm
+    baseURL: https://nominatim.example.com/
+    retry:
+      attempts: 3
+      backoff: 100ms
+chain: fastest
+timeout: 5s
+cache:
+  ttl: 1h
+`
+
+const jsonFixture = `{
+  "providers": [
+    {"name": "google", "key": "${GEO_TEST_KEY}", "timeout": "2s", "rate": 10},
+    {"name": "osm", "baseURL": "https://nominatim.example.com/", "retry": {"attempts": 3, "backoff": "100ms"}}
+  ],
+  "chain": "fastest",
+  "timeout": "5s",
+  "cache": {"ttl": "1h"}
+}`
+
+func TestParse(t *testing.T) {
+	os.Setenv("GEO_TEST_KEY", "secret")
+	defer os.Unsetenv("GEO_TEST_KEY")
+
+	expected := config.Config{
+		Providers: []config.Provider{
+			{Name: "google", Key: "secret", Timeout: config.Duration(2 * time.Second), Rate: 10},
+			{Name: "osm", BaseURL: "https://nominatim.example.com/", Retry: &config.Retry{Attempts: 3, Backoff: config.Duration(100 * time.Millisecond)}},
+		},
+		Chain:   config.Fastest,
+		Timeout: config.Duration(5 * time.Second),
+		Cache:   &config.Cache{TTL: config.Duration(time.Hour)},
+	}
+
+	c, err := config.ParseYAML([]byte(yamlFixture))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	c, err = config.ParseJSON([]byte(jsonFixture))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	g, err := c.Build()
+	assert.NoError(t, err)
+	assert.NotNil(t, g)
+}
+
+func TestLoad(t *testing.T) {
+	os.Setenv("GEO_TEST_KEY", "secret")
+	defer os.Unsetenv("GEO_TEST_KEY")
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{"geo.yaml": yamlFixture, "geo.json": jsonFixture} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		c, err := config.Load(path)
+		assert.NoError(t, err, name)
+		assert.Equal(t, "secret", c.Providers[0].Key, name)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	os.Unsetenv("GEO_TEST_KEY")
+	_, err := config.ParseYAML([]byte(yamlFixture))
+	assert.EqualError(t, err, "environment variable GEO_TEST_KEY is not set")
+
+	_, err = config.ParseYAML([]byte("providers:\n  - name: osm\n    url: https://nominatim.example.com/\n"))
+	assert.Error(t, err)
+
+	_, err = config.ParseYAML([]byte("timeout: soon\n"))
+	assert.Error(t, err)
+}
+
+func TestBuildErrors(t *testing.T) {
+	for c, msg := range map[*config.Config]string{
+		{}: "no providers configured",
+		{Providers: []config.Provider{{Name: "nowhere"}}}:                                    "provider nowhere: unknown provider",
+		{Providers: []config.Provider{{Name: "google"}}}:                                     "provider google: credentials are missing",
+		{Providers: []config.Provider{{Name: "osm"}}, Chain: "random"}:                       "unknown chain \"random\"",
+		{Providers: []config.Provider{{Name: "osm", Cache: &config.Cache{Backend: "disk"}}}}: "provider osm: unknown cache backend \"disk\"",
+	} {
+		_, err := c.Build()
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), msg)
+		}
+	}
+}
+
+// flaky fails its first request, and counts them all
+type flaky struct {
+	geo.Geocoder
+	calls *int
+}
+
+func (f flaky) Geocode(address string) (*geo.Location, error) {
+	if *f.calls++; *f.calls == 1 {
+		return nil, errors.New("flaky")
+	}
+	return f.Geocoder.Geocode(address)
+}
+
+func TestRegister(t *testing.T) {
+	calls := 0
+	geo.Register("test", func(o geo.Options) (geo.Geocoder, error) {
+		return flaky{
+			Geocoder: data.Geocoder(data.AddressToLocation{addressFixture: locationFixture}, data.LocationToAddress{}),
+			calls:    &calls,
+		}, nil
+	})
+
+	c, err := config.ParseYAML([]byte(`
+providers:
+  - name: test
+    retry:
+      attempts: 2
+    cache: {}
+`))
+	assert.NoError(t, err)
+	g, err := c.Build()
+	assert.NoError(t, err)
+
+	// the first request fails and is tried again, then the result is cached
+	for i := 0; i < 2; i++ {
+		location, err := g.Geocode(addressFixture.FormattedAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, locationFixture, *location)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestProviderTimeout(t *testing.T) {
+	var timeout time.Duration
+	geo.Register("slow", func(o geo.Options) (geo.Geocoder, error) {
+		timeout = o.Timeout
+		return data.Geocoder(data.AddressToLocation{}, data.LocationToAddress{}), nil
+	})
+
+	c, err := config.ParseYAML([]byte(`
+providers:
+  - name: slow
+timeout: 30s
+`))
+	assert.NoError(t, err)
+	_, err = c.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, timeout)
+}
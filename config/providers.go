@@ -0,0 +1,21 @@
+package config
+
+import (
+	// the providers of geo-golang register themselves with geo, to be configured by name
+	_ "github.com/codingsince1985/geo-golang/arcgis"
+	_ "github.com/codingsince1985/geo-golang/bing"
+	_ "github.com/codingsince1985/geo-golang/frenchapigouv"
+	_ "github.com/codingsince1985/geo-golang/geocod"
+	_ "github.com/codingsince1985/geo-golang/google"
+	_ "github.com/codingsince1985/geo-golang/here"
+	_ "github.com/codingsince1985/geo-golang/locationiq"
+	_ "github.com/codingsince1985/geo-golang/mapbox"
+	_ "github.com/codingsince1985/geo-golang/mapquest/nominatim"
+	_ "github.com/codingsince1985/geo-golang/mapquest/open"
+	_ "github.com/codingsince1985/geo-golang/mapzen"
+	_ "github.com/codingsince1985/geo-golang/opencage"
+	_ "github.com/codingsince1985/geo-golang/openstreetmap"
+	_ "github.com/codingsince1985/geo-golang/pickpoint"
+	_ "github.com/codingsince1985/geo-golang/tomtom"
+	_ "github.com/codingsince1985/geo-golang/yandex"
+)
@@ -0,0 +1,176 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNotCoordinates occurs when a string is in none of the coordinate notations ParseCoordinates understands
+var ErrNotCoordinates = errors.New("not coordinates")
+
+// ParseCoordinates returns the location written in s as a full Plus Code, an MGRS grid reference, UTM coordinates,
+// or latitude and longitude in decimal degrees or degrees, minutes and seconds
+func ParseCoordinates(s string) (Location, error) {
+	s = strings.TrimSpace(s)
+	if b, err := DecodePlusCode(s); err == nil {
+		return b.Center(), nil
+	}
+	if m, err := ParseMGRS(s); err == nil {
+		return m.Location()
+	}
+	if u, err := ParseUTM(s); err == nil {
+		return u.Location(), nil
+	}
+	if l, err := ParseDMS(s); err == nil {
+		return l, nil
+	}
+	return Location{}, ErrNotCoordinates
+}
+
+// dmsComponent is a latitude or longitude being parsed, with up to degrees, minutes and seconds
+type dmsComponent struct {
+	parts      []float64
+	negative   bool
+	hemisphere rune
+	fraction   bool // the last part has decimals, so no smaller part follows
+}
+
+func (c *dmsComponent) value() float64 {
+	v := 0.0
+	for i, p := range c.parts {
+		v += p / math.Pow(60, float64(i))
+	}
+	if c.negative || c.hemisphere == 'S' || c.hemisphere == 'W' {
+		return -v
+	}
+	return v
+}
+
+// ParseDMS returns the location written in s as latitude and longitude, each in decimal degrees, degrees and
+// decimal minutes, or degrees, minutes and seconds, such as "-37.8136, 144.9631" or "37°48'49"S 144°57'47"E".
+// Latitude comes first unless hemisphere letters tell otherwise
+func ParseDMS(s string) (Location, error) {
+	var components []*dmsComponent
+	current := &dmsComponent{}
+	closeComponent := func() {
+		if len(current.parts) > 0 {
+			components = append(components, current)
+		}
+		current = &dmsComponent{}
+	}
+	// mark is what follows the last number: none, a mark of degrees or minutes, or one of seconds
+	const (
+		none = iota
+		degreesOrMinutes
+		seconds
+	)
+	mark := none
+
+	runes := []rune(strings.ToUpper(strings.TrimSpace(s)))
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '-' || r == '+' || r == '.' || unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			text := string(runes[i:j])
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return Location{}, fmt.Errorf("invalid number %q in %q", text, s)
+			}
+			signed := r == '-' || r == '+'
+			// a number starts a new component unless it can be the minutes or seconds of the current one
+			if len(current.parts) > 0 && (signed || current.fraction ||
+				len(current.parts) == 3 || mark == seconds || mark == none && v >= 60) {
+				closeComponent()
+			}
+			current.negative = current.negative || r == '-'
+			current.parts = append(current.parts, math.Abs(v))
+			current.fraction = strings.Contains(text, ".")
+			mark = none
+			i = j
+		case r == '\'' && i+1 < len(runes) && runes[i+1] == '\'':
+			mark = seconds
+			i += 2
+		case r == '°' || r == 'º' || r == '˚' || r == '\'' || r == '′' || r == '’' || r == ':':
+			mark = degreesOrMinutes
+			i++
+		case r == '"' || r == '″' || r == '”':
+			mark = seconds
+			i++
+		case r == 'N' || r == 'S' || r == 'E' || r == 'W':
+			if len(current.parts) > 0 {
+				// a suffix ends its component
+				current.hemisphere = r
+				closeComponent()
+			} else {
+				current.hemisphere = r
+			}
+			mark = none
+			i++
+		case r == ',' || r == ';' || r == '/':
+			closeComponent()
+			mark = none
+			i++
+		case unicode.IsSpace(r):
+			i++
+		default:
+			return Location{}, fmt.Errorf("unexpected %q in %q", r, s)
+		}
+	}
+	closeComponent()
+
+	if len(components) != 2 {
+		return Location{}, fmt.Errorf("%q is not a latitude and a longitude", s)
+	}
+	lat, lng := components[0], components[1]
+	if lat.hemisphere == 'E' || lat.hemisphere == 'W' || lng.hemisphere == 'N' || lng.hemisphere == 'S' {
+		lat, lng = lng, lat
+	}
+	for _, c := range []*dmsComponent{lat, lng} {
+		for i, p := range c.parts {
+			if i > 0 && p >= 60 {
+				return Location{}, fmt.Errorf("invalid minutes or seconds %v in %q", p, s)
+			}
+		}
+	}
+	if lat.hemisphere == 'E' || lat.hemisphere == 'W' || lng.hemisphere == 'N' || lng.hemisphere == 'S' {
+		return Location{}, fmt.Errorf("%q has two latitudes or two longitudes", s)
+	}
+
+	l := Location{Lat: lat.value(), Lng: lng.value()}
+	return l, l.Validate()
+}
+
+// FormatDMS returns l in degrees, minutes and seconds with decimals digits after the point of the seconds,
+// such as 37°48'49"S 144°57'47"E
+func FormatDMS(l Location, decimals int) string {
+	lat, lng := "N", "E"
+	if l.Lat < 0 {
+		lat = "S"
+	}
+	if l.Lng < 0 {
+		lng = "W"
+	}
+	return formatDMS(math.Abs(l.Lat), decimals) + lat + " " + formatDMS(math.Abs(l.Lng), decimals) + lng
+}
+
+func formatDMS(v float64, decimals int) string {
+	// round to the precision written, carrying over to minutes and degrees
+	scale := math.Pow(10, float64(decimals))
+	total := math.Round(v * 3600 * scale)
+	sec := math.Mod(total, 60*scale) / scale
+	min := math.Mod(math.Floor(total/(60*scale)), 60)
+	deg := math.Floor(total / (3600 * scale))
+	width := 2
+	if decimals > 0 {
+		width = decimals + 3
+	}
+	return fmt.Sprintf("%.0f°%02.0f'%0*.*f\"", deg, min, width, decimals, sec)
+}
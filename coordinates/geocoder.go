@@ -0,0 +1,59 @@
+// Package coordinates is a geocoder resolving coordinates written as latitude and longitude, UTM, MGRS or Plus Codes
+// without calling a provider, and passing any other query on to another geocoder
+package coordinates
+
+import (
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+type coordinatesGeocoder struct{ next geo.Geocoder }
+
+// Geocoder resolves coordinate queries locally, and the others with next, which may be nil.
+// A short Plus Code followed by a locality, such as "CWC8+R9 Mountain View", is recovered near the locality geocoded by next
+func Geocoder(next geo.Geocoder) geo.Geocoder { return coordinatesGeocoder{next: next} }
+
+// Geocode returns location for address
+func (c coordinatesGeocoder) Geocode(address string) (*geo.Location, error) {
+	if l, err := geo.ParseCoordinates(address); err == nil {
+		return &l, nil
+	}
+	if c.next == nil {
+		return nil, nil
+	}
+
+	if fields := strings.Fields(address); len(fields) > 1 && geo.IsShortPlusCode(strings.TrimRight(fields[0], ",")) {
+		code := strings.TrimRight(fields[0], ",")
+		return c.nearLocality(code, strings.TrimLeft(strings.TrimPrefix(strings.TrimSpace(address), code), " ,"))
+	}
+	return c.next.Geocode(address)
+}
+
+// nearLocality returns the center of the short Plus Code nearest the locality geocoded by next
+func (c coordinatesGeocoder) nearLocality(code, locality string) (*geo.Location, error) {
+	reference, err := c.next.Geocode(locality)
+	if err != nil || reference == nil {
+		return reference, err
+	}
+	if code, err = geo.RecoverPlusCode(code, *reference); err != nil {
+		return nil, err
+	}
+	b, err := geo.DecodePlusCode(code)
+	if err != nil {
+		return nil, err
+	}
+	l := b.Center()
+	return &l, nil
+}
+
+// ReverseGeocode returns address for location
+func (c coordinatesGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	if c.next == nil {
+		return nil, nil
+	}
+	return c.next.ReverseGeocode(lat, lng)
+}
+
+// Capabilities returns those of next
+func (c coordinatesGeocoder) Capabilities() geo.Capabilities { return geo.CapabilitiesOf(c.next) }
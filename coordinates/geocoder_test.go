@@ -0,0 +1,76 @@
+package coordinates_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/coordinates"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addressFixture  = geo.Address{FormattedAddress: "Mountain View, CA, USA"}
+	locationFixture = geo.Location{Lat: 37.3861, Lng: -122.0839}
+	geocoder        = coordinates.Geocoder(data.Geocoder(
+		data.AddressToLocation{addressFixture: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture},
+	))
+)
+
+func TestGeocode(t *testing.T) {
+	for _, query := range []string{
+		"37.3861, -122.0839",
+		`37°23'10"N 122°05'02"W`,
+		"10S 581098 4138099",
+		"10S EG 81097 38098",
+		"849V9WP8+CC",
+	} {
+		location, err := geocoder.Geocode(query)
+		assert.NoError(t, err, query)
+		assert.InDelta(t, locationFixture.Lat, location.Lat, 0.0001, query)
+		assert.InDelta(t, locationFixture.Lng, location.Lng, 0.0001, query)
+	}
+}
+
+func TestGeocodeShortPlusCode(t *testing.T) {
+	location, err := geocoder.Geocode("CWC8+R9, Mountain View, CA, USA")
+	assert.NoError(t, err)
+	code, _ := geo.EncodePlusCode(*location, 10)
+	assert.Equal(t, "849VCWC8+R9", code)
+
+	location, err = geocoder.Geocode("CWC8+R9 Nowhere")
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+}
+
+func TestGeocodeDelegates(t *testing.T) {
+	location, err := geocoder.Geocode(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, locationFixture, *location)
+
+	address, err := geocoder.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, addressFixture, *address)
+}
+
+func TestGeocodeWithoutNext(t *testing.T) {
+	g := coordinates.Geocoder(nil)
+	location, err := g.Geocode("-37.8136, 144.9631")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.8136, Lng: 144.9631}, *location)
+
+	location, err = g.Geocode("Melbourne")
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+
+	address, err := g.ReverseGeocode(-37.8136, 144.9631)
+	assert.NoError(t, err)
+	assert.Nil(t, address)
+}
+
+func TestGeocodeEmpty(t *testing.T) {
+	location, err := geocoder.Geocode("")
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+}
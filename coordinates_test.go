@@ -0,0 +1,146 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	eiffelTower      = geo.Location{Lat: 48.8583, Lng: 2.2945}
+	sydneyOperaHouse = geo.Location{Lat: -33.857, Lng: 151.215}
+)
+
+func TestParseDMS(t *testing.T) {
+	for _, s := range []string{
+		`37°48'49"S 144°57'47"E`,
+		`S 37° 48' 49", E 144° 57' 47"`,
+		`144°57′47″E 37°48′49″S`,
+		`37 48 49 S, 144 57 47 E`,
+		`-37:48:49 144:57:47`,
+		`37°48.8167'S 144°57.7833'E`,
+		`-37.81361, 144.96306`,
+		`-37.81361 144.96306`,
+	} {
+		l, err := geo.ParseDMS(s)
+		assert.NoError(t, err, s)
+		assert.InDelta(t, -37.81361, l.Lat, 0.00001, s)
+		assert.InDelta(t, 144.96306, l.Lng, 0.00001, s)
+	}
+
+	for _, s := range []string{
+		"", "37.8", "1 2 3 4 5 6 7", "37°61'S 144°E", "37N 144S", "91, 0", "abc", "12.3.4, 5",
+	} {
+		_, err := geo.ParseDMS(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestFormatDMS(t *testing.T) {
+	assert.Equal(t, `37°48'49"S 144°57'47"E`, geo.FormatDMS(geo.Location{Lat: -37.81361, Lng: 144.96306}, 0))
+	assert.Equal(t, `48°51'29.88"N 2°17'40.20"E`, geo.FormatDMS(eiffelTower, 2))
+	assert.Equal(t, `1°00'00"N 0°00'00"E`, geo.FormatDMS(geo.Location{Lat: 0.9999999}, 0))
+}
+
+func TestUTM(t *testing.T) {
+	u, err := geo.LocationToUTM(geo.Location{})
+	assert.NoError(t, err)
+	assert.Equal(t, 31, u.Zone)
+	assert.Equal(t, byte('N'), u.Band)
+	assert.InDelta(t, 166021.443, u.Easting, 0.001)
+	assert.InDelta(t, 0, u.Northing, 0.001)
+
+	u, err = geo.LocationToUTM(eiffelTower)
+	assert.NoError(t, err)
+	assert.Equal(t, "31U 448252 5411944", u.String())
+	l := u.Location()
+	assert.InDelta(t, eiffelTower.Lat, l.Lat, 1e-9)
+	assert.InDelta(t, eiffelTower.Lng, l.Lng, 1e-9)
+
+	u, err = geo.LocationToUTM(sydneyOperaHouse)
+	assert.NoError(t, err)
+	assert.Equal(t, "56H 334873 6252266", u.String())
+
+	// Norway and Svalbard
+	u, _ = geo.LocationToUTM(geo.Location{Lat: 60, Lng: 5})
+	assert.Equal(t, 32, u.Zone)
+	u, _ = geo.LocationToUTM(geo.Location{Lat: 78, Lng: 15})
+	assert.Equal(t, 33, u.Zone)
+
+	_, err = geo.LocationToUTM(geo.Location{Lat: 85})
+	assert.Error(t, err)
+}
+
+func TestParseUTM(t *testing.T) {
+	u, err := geo.ParseUTM("31U 448252mE 5411944mN")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.UTM{Zone: 31, Band: 'U', Easting: 448252, Northing: 5411944}, u)
+	l := u.Location()
+	assert.InDelta(t, eiffelTower.Lat, l.Lat, 0.00001)
+	assert.InDelta(t, eiffelTower.Lng, l.Lng, 0.00001)
+
+	for _, s := range []string{"31 448252 5411944", "61U 448252 5411944", "31I 448252 5411944", "31U 48252 5411944"} {
+		_, err := geo.ParseUTM(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestMGRS(t *testing.T) {
+	m, err := geo.LocationToMGRS(eiffelTower)
+	assert.NoError(t, err)
+	assert.Equal(t, "31U DQ 48251 11943", m.String())
+	assert.Equal(t, "31U DQ 482 119", m.Format(3))
+
+	m, err = geo.LocationToMGRS(sydneyOperaHouse)
+	assert.NoError(t, err)
+	assert.Equal(t, "56H LH 34873 52266", m.String())
+
+	for _, l := range []geo.Location{eiffelTower, sydneyOperaHouse, {Lat: -79.5, Lng: -170}, {Lat: 83.5, Lng: 20}, {Lat: 0.1, Lng: 0.1}, {Lat: -0.1, Lng: 0.1}} {
+		m, err := geo.LocationToMGRS(l)
+		assert.NoError(t, err)
+		u, err := m.UTM()
+		assert.NoError(t, err)
+		expected, _ := geo.LocationToUTM(l)
+		assert.InDelta(t, expected.Easting, u.Easting, 1e-6, m.String())
+		assert.InDelta(t, expected.Northing, u.Northing, 1e-6, m.String())
+	}
+}
+
+func TestParseMGRS(t *testing.T) {
+	for _, s := range []string{"31U DQ 48251 11943", "31udq4825111943", "31U DQ 4825111943"} {
+		m, err := geo.ParseMGRS(s)
+		assert.NoError(t, err, s)
+		l, err := m.Location()
+		assert.NoError(t, err)
+		assert.InDelta(t, eiffelTower.Lat, l.Lat, 0.00002, s)
+		assert.InDelta(t, eiffelTower.Lng, l.Lng, 0.00002, s)
+	}
+
+	m, err := geo.ParseMGRS("31U DQ 482 119")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.MGRS{Zone: 31, Band: 'U', Square: "DQ", Easting: 48200, Northing: 11900}, m)
+
+	for _, s := range []string{"31U DQ 4825 119", "31U JQ 48251 11943", "31U DW 48251 11932", "61U DQ 1 1"} {
+		_, err := geo.ParseMGRS(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseCoordinates(t *testing.T) {
+	for s, expected := range map[string]geo.Location{
+		"8FW4V75V+8Q":          {Lat: 48.8583125, Lng: 2.2944375},
+		"31U DQ 48251 11943":   {Lat: 48.8583, Lng: 2.2945},
+		"31U 448252 5411944":   eiffelTower,
+		`48°51'30"N 2°17'40"E`: {Lat: 48.858333, Lng: 2.294444},
+		"48.8583, 2.2945":      eiffelTower,
+	} {
+		l, err := geo.ParseCoordinates(s)
+		assert.NoError(t, err, s)
+		assert.InDelta(t, expected.Lat, l.Lat, 0.00002, s)
+		assert.InDelta(t, expected.Lng, l.Lng, 0.00002, s)
+	}
+
+	_, err := geo.ParseCoordinates("Eiffel Tower, Paris")
+	assert.Equal(t, geo.ErrNotCoordinates, err)
+}
@@ -0,0 +1,61 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCountry(t *testing.T) {
+	australia := geo.Country{Alpha2: "AU", Alpha3: "AUS", Numeric: "036", Name: "Australia"}
+	for _, s := range []string{"AU", "au", "AUS", "036", "Australia", " AUSTRALIA "} {
+		c, ok := geo.LookupCountry(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, australia, c, s)
+	}
+
+	for s, code := range map[string]string{
+		"United States": "US", "USA": "US", "UK": "GB", "Россия": "RU", "Deutschland": "DE", "Côte d'Ivoire": "CI",
+	} {
+		c, ok := geo.LookupCountry(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, code, c.Alpha2, s)
+	}
+
+	for _, s := range []string{"", "XX", "Atlantis"} {
+		_, ok := geo.LookupCountry(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestCountries(t *testing.T) {
+	countries := geo.Countries()
+	assert.Len(t, countries, 249)
+	seen := map[string]bool{}
+	for _, c := range countries {
+		for _, key := range []string{c.Alpha2, c.Alpha3, c.Numeric} {
+			assert.False(t, seen[key], key)
+			seen[key] = true
+		}
+		assert.Len(t, c.Alpha2, 2)
+		assert.Len(t, c.Alpha3, 3)
+		assert.Len(t, c.Numeric, 3)
+	}
+}
+
+func TestNormalizeCountry(t *testing.T) {
+	for _, a := range []geo.Address{
+		{CountryCode: "FRA"},
+		{CountryCode: "fr", Country: "République française"},
+		{Country: "France"},
+		{Country: "FRA"},
+	} {
+		a.NormalizeCountry()
+		assert.Equal(t, geo.Address{CountryCode: "FR", Country: "France"}, a)
+	}
+
+	a := geo.Address{Country: "Kosovo", CountryCode: "XK"}
+	a.NormalizeCountry()
+	assert.Equal(t, geo.Address{Country: "Kosovo", CountryCode: "XK"}, a)
+}
@@ -0,0 +1,20 @@
+package data
+
+import (
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/feature"
+)
+
+// FromFeatures returns the data of features with an address, such as those decoded from GeoJSON or KML,
+// geocoding their formatted address and reverse geocoding their location
+func FromFeatures(features ...feature.Feature) (AddressToLocation, LocationToAddress) {
+	addressToLocation, locationToAddress := AddressToLocation{}, LocationToAddress{}
+	for _, f := range features {
+		if f.Address == nil {
+			continue
+		}
+		addressToLocation[geo.Address{FormattedAddress: f.Address.FormattedAddress}] = f.Location
+		locationToAddress[f.Location] = *f.Address
+	}
+	return addressToLocation, locationToAddress
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/codingsince1985/geo-golang"
 	"github.com/codingsince1985/geo-golang/data"
+	"github.com/codingsince1985/geo-golang/feature"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +46,24 @@ func TestReverseGeocodeWithNoResult(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, addr)
 }
+
+func TestFromFeatures(t *testing.T) {
+	features, err := feature.ParseGeoJSON([]byte(`{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [144.96328, -37.814107]},
+		 "properties": {"formattedAddress": "64 Elizabeth Street, Melbourne, Victoria 3000, Australia", "postcode": "3000"}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {}}]}`))
+	assert.NoError(t, err)
+	g := data.Geocoder(data.FromFeatures(features...))
+
+	location, err := g.Geocode(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, locationFixture, *location)
+
+	address, err := g.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, "3000", address.Postcode)
+
+	address, err = g.ReverseGeocode(0, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, address)
+}
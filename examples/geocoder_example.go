@@ -100,74 +100,74 @@ func ExampleGeocoder() {
 	// Address of (-37.813611,144.963056) is 197 Elizabeth St, Melbourne VIC 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"197 Elizabeth St, Melbourne VIC 3000, Australia",
 	// 	Street:"Elizabeth Street", HouseNumber:"197", Suburb:"", Postcode:"3000", State:"Victoria",
-	// 	StateDistrict:"Melbourne City", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	StateDistrict:"Melbourne City", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// Mapquest Nominatim
 	// Melbourne VIC location is (-37.814218, 144.963161)
 	// Address of (-37.813611,144.963056) is Melbourne's GPO, Postal Lane, Melbourne, City of Melbourne, Greater Melbourne, Victoria, 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's GPO, Postal Lane, Melbourne, City of Melbourne,
 	// 	Greater Melbourne, Victoria, 3000, Australia", Street:"Postal Lane", HouseNumber:"", Suburb:"Melbourne",
-	// 	Postcode:"3000", State:"Victoria", StateDistrict:"", County:"City of Melbourne", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	Postcode:"3000", State:"Victoria", StateDistrict:"", County:"City of Melbourne", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// Mapquest Open streetmaps
 	// Melbourne VIC location is (-37.814218, 144.963161)
 	// Address of (-37.813611,144.963056) is Elizabeth Street, Melbourne, Victoria, AU
 	// Detailed address: &geo.Address{FormattedAddress:"Elizabeth Street, 3000, Melbourne, Victoria, AU",
 	// 	Street:"Elizabeth Street", HouseNumber:"", Suburb:"", Postcode:"3000", State:"Victoria", StateDistrict:"",
-	// 	County:"", Country:"", CountryCode:"AU", City:"Melbourne"}
+	// 	County:"", Country:"", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// OpenCage Data
 	// Melbourne VIC location is (-37.814217, 144.963161)
 	// Address of (-37.813611,144.963056) is Melbourne's GPO, Postal Lane, Melbourne VIC 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's GPO, Postal Lane, Melbourne VIC 3000, Australia",
 	// 	Street:"Postal Lane", HouseNumber:"", Suburb:"Melbourne (3000)", Postcode:"3000", State:"Victoria",
-	// 	StateDistrict:"", County:"City of Melbourne", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	StateDistrict:"", County:"City of Melbourne", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"Australia/Melbourne", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// HERE API
 	// Melbourne VIC location is (-37.817530, 144.967150)
 	// Address of (-37.813611,144.963056) is 197 Elizabeth St, Melbourne VIC 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"197 Elizabeth St, Melbourne VIC 3000, Australia", Street:"Elizabeth St",
 	// 	HouseNumber:"197", Suburb:"", Postcode:"3000", State:"Victoria", StateDistrict:"", County:"", Country:"Australia",
-	// 	CountryCode:"AUS", City:"Melbourne"}
+	// 	CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// Bing Geocoding API
 	// Melbourne VIC location is (-37.824299, 144.977997)
 	// Address of (-37.813611,144.963056) is Elizabeth St, Melbourne, VIC 3000
 	// Detailed address: &geo.Address{FormattedAddress:"Elizabeth St, Melbourne, VIC 3000", Street:"Elizabeth St",
-	// 	HouseNumber:"", Suburb:"", Postcode:"3000", State:"", StateDistrict:"", County:"", Country:"Australia", CountryCode:"", City:"Melbourne"}
+	// 	HouseNumber:"", Suburb:"", Postcode:"3000", State:"", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// Mapbox API
 	// Melbourne VIC location is (-37.814200, 144.963200)
 	// Address of (-37.813611,144.963056) is Elwood Park Playground, Melbourne, Victoria 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Elwood Park Playground, Melbourne, Victoria 3000, Australia",
 	// 	Street:"Elwood Park Playground", HouseNumber:"", Suburb:"", Postcode:"3000", State:"Victoria", StateDistrict:"",
-	// 	County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// OpenStreetMap
 	// Melbourne VIC location is (-37.814217, 144.963161)
 	// Address of (-37.813611,144.963056) is Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne, Victoria, 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne,
 	// 	Victoria, 3000, Australia", Street:"Postal Lane", HouseNumber:"", Suburb:"Melbourne", Postcode:"3000", State:"Victoria",
-	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// PickPoint
 	// Melbourne VIC location is (-37.814217, 144.963161)
 	// Address of (-37.813611,144.963056) is Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne, Victoria, 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne,
 	// 	Victoria, 3000, Australia", Street:"Postal Lane", HouseNumber:"", Suburb:"Melbourne", Postcode:"3000", State:"Victoria",
-	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// LocationIQ
 	// Melbourne VIC location is (-37.814217, 144.963161)
 	// Address of (-37.813611,144.963056) is Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne, Victoria, 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne,
 	// 	Victoria, 3000, Australia", Street:"Postal Lane", HouseNumber:"", Suburb:"Melbourne", Postcode:"3000", State:"Victoria",
-	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// ArcGIS
 	// Melbourne VIC location is (-37.817530, 144.967150)
 	// Address of (-37.813611,144.963056) is Melbourne's Gpo
-	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's Gpo", Street:"350 Bourke Street Mall", HouseNumber:"350", Suburb:"", Postcode:"3000", State:"Victoria", StateDistrict:"", County:"", Country:"", CountryCode:"AUS", City:""}
+	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's Gpo", Street:"350 Bourke Street Mall", HouseNumber:"350", Suburb:"", Postcode:"3000", State:"Victoria", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// geocod.io
 	// Melbourne VIC location is (28.079357, -80.623618)
@@ -176,33 +176,33 @@ func ExampleGeocoder() {
 	// Mapzen
 	// Melbourne VIC location is (45.551136, 11.533929)
 	// Address of (-37.813611,144.963056) is Stop 3: Bourke Street Mall, Bourke Street, Melbourne, Australia
-	// Detailed address: &geo.Address{FormattedAddress:"Stop 3: Bourke Street Mall, Bourke Street, Melbourne, Australia", Street:"", HouseNumber:"", Suburb:"", Postcode:"", State:"Victoria", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AUS", City:""}
+	// Detailed address: &geo.Address{FormattedAddress:"Stop 3: Bourke Street Mall, Bourke Street, Melbourne, Australia", Street:"", HouseNumber:"", Suburb:"", Postcode:"", State:"Victoria", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// TomTom
 	// Melbourne VIC location is (-37.815340, 144.963230)
 	// Address of (-37.813611,144.963056) is Doyles Road, Elaine, West Central Victoria, Victoria, 3334
-	// Detailed address: &geo.Address{FormattedAddress:"Doyles Road, Elaine, West Central Victoria, Victoria, 3334", Street:"Doyles Road", HouseNumber:"", Suburb:"", Postcode:"3334", State:"Victoria", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Elaine"}
+	// Detailed address: &geo.Address{FormattedAddress:"Doyles Road, Elaine, West Central Victoria, Victoria, 3334", Street:"Doyles Road", HouseNumber:"", Suburb:"", Postcode:"3334", State:"Victoria", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Elaine", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// Yandex
 	// Melbourne VIC location is (41.926823, 2.254232)
 	// Address of (-37.813611,144.963056) is Victoria, City of Melbourne, Elizabeth Street
 	// Detailed address: &geo.Address{FormattedAddress:"Victoria, City of Melbourne, Elizabeth Street", Street:"Elizabeth Street",
 	//  HouseNumber:"", Suburb:"", Postcode:"", State:"Victoria", StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU",
-	//  City:"City of Melbourne"}
+	//  City:"City of Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// FrenchAPIGouv
 	//  Champs de Mars Paris location is (2.304770, 48.854395)
-	//  Address of (48.854395,2.304770) is 9001, Parc du Champs de Mars, 75007, Paris, Paris, Île-de-France, France
-	//  Detailed address: &geo.Address{FormattedAddress:"9001, Parc du Champs de Mars, 75007, Paris, Paris, Île-de-France, France",
-	//   Street:"Parc du Champs de Mars", HouseNumber:"9001", Suburb:"", Postcode:"75007", State:" Île-de-France", StateDistrict:"",
-	//   County:" Paris", Country:"France", CountryCode:"FRA", City:"Paris"}
+	//  Address of (48.854395,2.304770) is 9001 Parc du Champs de Mars, 75007 Paris, France
+	//  Detailed address: &geo.Address{FormattedAddress:"9001 Parc du Champs de Mars, 75007 Paris, France",
+	//   Street:"Parc du Champs de Mars", HouseNumber:"9001", Suburb:"", Postcode:"75007", State:"Île-de-France", StateDistrict:"",
+	//   County:"Paris", Country:"France", CountryCode:"FR", City:"Paris", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 	//
 	// ChainedAPI[OpenStreetmap -> Google]
 	// Melbourne VIC location is (-37.814217, 144.963161)
 	// Address of (-37.813611,144.963056) is Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne, Victoria, 3000, Australia
 	// Detailed address: &geo.Address{FormattedAddress:"Melbourne's GPO, Postal Lane, Chinatown, Melbourne, City of Melbourne, Greater Melbourne,
 	// 	Victoria, 3000, Australia", Street:"Postal Lane", HouseNumber:"", Suburb:"Melbourne", Postcode:"3000", State:"Victoria",
-	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne"}
+	// 	StateDistrict:"", County:"", Country:"Australia", CountryCode:"AU", City:"Melbourne", Timezone:"", Source:(*geo.Source)(0xc0001a6000)}
 }
 
 func try(geocoder geo.Geocoder) {
@@ -0,0 +1,65 @@
+// Package feature encodes geocoding results as GeoJSON, WKT, WKB and KML, and decodes them back,
+// for use with spatial databases, web maps and the data geocoder
+package feature
+
+import "github.com/codingsince1985/geo-golang"
+
+// Feature is a geocoded place: its location, with the address and extent when known
+type Feature struct {
+	Location    geo.Location
+	Address     *geo.Address
+	BoundingBox *geo.BoundingBox
+}
+
+// FromResult returns the feature of a result of GeocodeResult, with the address it was geocoded from if not nil
+func FromResult(r geo.Result, address *geo.Address) Feature {
+	return Feature{Location: r.Location, Address: address, BoundingBox: r.BoundingBox}
+}
+
+// addressFields are the names of the fields of geo.Address in GeoJSON properties and KML extended data
+var addressFields = []struct {
+	name  string
+	field func(*geo.Address) *string
+}{
+	{"formattedAddress", func(a *geo.Address) *string { return &a.FormattedAddress }},
+	{"street", func(a *geo.Address) *string { return &a.Street }},
+	{"houseNumber", func(a *geo.Address) *string { return &a.HouseNumber }},
+	{"suburb", func(a *geo.Address) *string { return &a.Suburb }},
+	{"postcode", func(a *geo.Address) *string { return &a.Postcode }},
+	{"state", func(a *geo.Address) *string { return &a.State }},
+	{"stateDistrict", func(a *geo.Address) *string { return &a.StateDistrict }},
+	{"county", func(a *geo.Address) *string { return &a.County }},
+	{"country", func(a *geo.Address) *string { return &a.Country }},
+	{"countryCode", func(a *geo.Address) *string { return &a.CountryCode }},
+	{"city", func(a *geo.Address) *string { return &a.City }},
+}
+
+// addressProperties returns the non empty fields of a by name
+func addressProperties(a *geo.Address) map[string]string {
+	properties := map[string]string{}
+	if a == nil {
+		return properties
+	}
+	for _, f := range addressFields {
+		if v := *f.field(a); v != "" {
+			properties[f.name] = v
+		}
+	}
+	return properties
+}
+
+// propertiesAddress returns the address of the named fields in properties, or nil if there are none
+func propertiesAddress(properties map[string]string) *geo.Address {
+	var a geo.Address
+	found := false
+	for _, f := range addressFields {
+		if v, ok := properties[f.name]; ok {
+			*f.field(&a) = v
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &a
+}
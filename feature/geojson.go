@@ -0,0 +1,151 @@
+package feature
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// Collection is a set of features, such as the results of a batch, encoded as a GeoJSON FeatureCollection
+type Collection []Feature
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string                     `json:"type"`
+	BBox       []float64                  `json:"bbox,omitempty"`
+	Geometry   *geoJSONGeometry           `json:"geometry"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+type geoJSONCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// MarshalJSON encodes f as a GeoJSON Feature with a Point geometry, the address fields as properties,
+// and the bounding box as bbox, west to east possibly crossing the antimeridian
+func (f Feature) MarshalJSON() ([]byte, error) {
+	coordinates, _ := json.Marshal([2]float64{f.Location.Lng, f.Location.Lat})
+	properties := map[string]json.RawMessage{}
+	for name, v := range addressProperties(f.Address) {
+		properties[name], _ = json.Marshal(v)
+	}
+	g := geoJSONFeature{
+		Type:       "Feature",
+		Geometry:   &geoJSONGeometry{Type: "Point", Coordinates: coordinates},
+		Properties: properties,
+	}
+	if b := f.BoundingBox; b != nil {
+		g.BBox = []float64{b.West, b.South, b.East, b.North}
+	}
+	return json.Marshal(g)
+}
+
+// UnmarshalJSON decodes a GeoJSON Feature with a Point geometry, taking the address from its properties
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var g geoJSONFeature
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Feature" {
+		return fmt.Errorf("GeoJSON %q is not a Feature", g.Type)
+	}
+	if g.Geometry == nil {
+		return errors.New("GeoJSON Feature has no geometry")
+	}
+	l, err := point(*g.Geometry)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]string{}
+	for name, raw := range g.Properties {
+		var v string
+		if json.Unmarshal(raw, &v) == nil {
+			properties[name] = v
+		}
+	}
+	*f = Feature{Location: l, Address: propertiesAddress(properties)}
+	switch len(g.BBox) {
+	case 0:
+	case 4:
+		f.BoundingBox = &geo.BoundingBox{West: g.BBox[0], South: g.BBox[1], East: g.BBox[2], North: g.BBox[3]}
+	default:
+		return fmt.Errorf("unsupported GeoJSON bbox of %d values", len(g.BBox))
+	}
+	return nil
+}
+
+// point returns the location of a GeoJSON Point
+func point(g geoJSONGeometry) (geo.Location, error) {
+	if g.Type != "Point" {
+		return geo.Location{}, fmt.Errorf("unsupported GeoJSON geometry %q", g.Type)
+	}
+	var coordinates []float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		return geo.Location{}, err
+	}
+	if len(coordinates) < 2 {
+		return geo.Location{}, fmt.Errorf("GeoJSON Point has %d coordinates", len(coordinates))
+	}
+	l := geo.Location{Lat: coordinates[1], Lng: coordinates[0]}
+	return l, l.Validate()
+}
+
+// MarshalJSON encodes c as a GeoJSON FeatureCollection
+func (c Collection) MarshalJSON() ([]byte, error) {
+	features := []Feature(c)
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(geoJSONCollection{Type: "FeatureCollection", Features: features})
+}
+
+// UnmarshalJSON decodes a GeoJSON FeatureCollection of Point features
+func (c *Collection) UnmarshalJSON(data []byte) error {
+	var g geoJSONCollection
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "FeatureCollection" {
+		return fmt.Errorf("GeoJSON %q is not a FeatureCollection", g.Type)
+	}
+	*c = g.Features
+	return nil
+}
+
+// ParseGeoJSON returns the features of a GeoJSON FeatureCollection, Feature or Point
+func ParseGeoJSON(data []byte) ([]Feature, error) {
+	var object struct{ Type string }
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	switch object.Type {
+	case "FeatureCollection":
+		var c Collection
+		err := json.Unmarshal(data, &c)
+		return c, err
+	case "Feature":
+		var f Feature
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, err
+		}
+		return []Feature{f}, nil
+	default:
+		var g geoJSONGeometry
+		if err := json.Unmarshal(data, &g); err != nil {
+			return nil, err
+		}
+		l, err := point(g)
+		if err != nil {
+			return nil, err
+		}
+		return []Feature{{Location: l}}, nil
+	}
+}
@@ -0,0 +1,76 @@
+package feature_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	melbourne = feature.Feature{
+		Location: geo.Location{Lat: -37.814107, Lng: 144.96328},
+		Address: &geo.Address{
+			FormattedAddress: "64 Elizabeth Street, Melbourne, Victoria 3000, Australia",
+			HouseNumber:      "64",
+			Street:           "Elizabeth Street",
+			City:             "Melbourne",
+			Postcode:         "3000",
+			CountryCode:      "AU",
+		},
+	}
+	fiji = feature.Feature{
+		Location:    geo.Location{Lat: -17.7134, Lng: 178.065},
+		BoundingBox: &geo.BoundingBox{South: -21.0, West: 176.9, North: -12.5, East: -178.2},
+	}
+)
+
+func TestMarshalGeoJSON(t *testing.T) {
+	b, err := json.Marshal(melbourne)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "Feature",
+		"geometry": {"type": "Point", "coordinates": [144.96328, -37.814107]},
+		"properties": {"formattedAddress": "64 Elizabeth Street, Melbourne, Victoria 3000, Australia",
+			"houseNumber": "64", "street": "Elizabeth Street", "city": "Melbourne", "postcode": "3000", "countryCode": "AU"}}`,
+		string(b))
+
+	b, err = json.Marshal(feature.Collection{fiji})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "FeatureCollection", "features": [{"type": "Feature",
+		"bbox": [176.9, -21, -178.2, -12.5],
+		"geometry": {"type": "Point", "coordinates": [178.065, -17.7134]},
+		"properties": {}}]}`, string(b))
+
+	b, err = json.Marshal(feature.Collection(nil))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "FeatureCollection", "features": []}`, string(b))
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	b, _ := json.Marshal(feature.Collection{melbourne, fiji})
+	features, err := feature.ParseGeoJSON(b)
+	assert.NoError(t, err)
+	assert.Equal(t, []feature.Feature{melbourne, fiji}, features)
+
+	b, _ = json.Marshal(melbourne)
+	features, err = feature.ParseGeoJSON(b)
+	assert.NoError(t, err)
+	assert.Equal(t, []feature.Feature{melbourne}, features)
+
+	features, err = feature.ParseGeoJSON([]byte(`{"type": "Point", "coordinates": [2.2945, 48.8583, 35]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []feature.Feature{{Location: geo.Location{Lat: 48.8583, Lng: 2.2945}}}, features)
+
+	for _, s := range []string{
+		`[]`,
+		`{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`,
+		`{"type": "Feature", "geometry": null, "properties": {}}`,
+		`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 91]}}`,
+		`{"type": "FeatureCollection", "features": [{"type": "Point", "coordinates": [0, 0]}]}`,
+	} {
+		_, err := feature.ParseGeoJSON([]byte(s))
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,117 @@
+package feature
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"kml"`
+	Namespace  string         `xml:"xmlns,attr"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name         string        `xml:"name,omitempty"`
+	Address      string        `xml:"address,omitempty"`
+	ExtendedData []kmlData     `xml:"ExtendedData>Data,omitempty"`
+	Point        *kmlPoint     `xml:"Point"`
+	Region       *kmlLatLonBox `xml:"Region>LatLonAltBox"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLatLonBox struct {
+	North float64 `xml:"north"`
+	South float64 `xml:"south"`
+	East  float64 `xml:"east"`
+	West  float64 `xml:"west"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// KML returns a KML document with a placemark for each feature, named by its formatted address,
+// with the address fields as extended data and the bounding box as a region
+func KML(features ...Feature) ([]byte, error) {
+	doc := kmlDocument{Namespace: kmlNamespace, Placemarks: make([]kmlPlacemark, len(features))}
+	for i, f := range features {
+		p := &doc.Placemarks[i]
+		if f.Address != nil {
+			p.Name, p.Address = f.Address.FormattedAddress, f.Address.FormattedAddress
+		}
+		properties := addressProperties(f.Address)
+		for _, field := range addressFields {
+			if v, ok := properties[field.name]; ok {
+				p.ExtendedData = append(p.ExtendedData, kmlData{Name: field.name, Value: v})
+			}
+		}
+		p.Point = &kmlPoint{Coordinates: strconv.FormatFloat(f.Location.Lng, 'f', -1, 64) + "," + strconv.FormatFloat(f.Location.Lat, 'f', -1, 64)}
+		if b := f.BoundingBox; b != nil {
+			p.Region = &kmlLatLonBox{North: b.North, South: b.South, East: b.East, West: b.West}
+		}
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// ParseKML returns the features of the point placemarks in a KML document, skipping the other placemarks.
+// The address is taken from the extended data named as KML writes it, or else from the address element
+func ParseKML(data []byte) ([]Feature, error) {
+	var doc struct {
+		Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+		Folders    []kmlPlacemark `xml:"Document>Folder>Placemark"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var features []Feature
+	for _, p := range append(doc.Placemarks, doc.Folders...) {
+		if p.Point == nil {
+			continue
+		}
+		// longitude, latitude and an optional altitude
+		c := strings.Split(strings.TrimSpace(p.Point.Coordinates), ",")
+		if len(c) < 2 || len(c) > 3 {
+			return nil, fmt.Errorf("invalid KML coordinates %q", p.Point.Coordinates)
+		}
+		lng, errLng := strconv.ParseFloat(strings.TrimSpace(c[0]), 64)
+		lat, errLat := strconv.ParseFloat(strings.TrimSpace(c[1]), 64)
+		if errLng != nil || errLat != nil {
+			return nil, fmt.Errorf("invalid KML coordinates %q", p.Point.Coordinates)
+		}
+		f := Feature{Location: geo.Location{Lat: lat, Lng: lng}}
+		if err := f.Location.Validate(); err != nil {
+			return nil, err
+		}
+
+		properties := map[string]string{}
+		for _, d := range p.ExtendedData {
+			properties[d.Name] = d.Value
+		}
+		if _, ok := properties["formattedAddress"]; !ok && p.Address != "" {
+			properties["formattedAddress"] = p.Address
+		}
+		f.Address = propertiesAddress(properties)
+		if r := p.Region; r != nil {
+			f.BoundingBox = &geo.BoundingBox{South: r.South, West: r.West, North: r.North, East: r.East}
+		}
+		features = append(features, f)
+	}
+	return features, nil
+}
@@ -0,0 +1,49 @@
+package feature_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKML(t *testing.T) {
+	b, err := feature.KML(melbourne, fiji)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `<kml xmlns="http://www.opengis.net/kml/2.2">`)
+	assert.Contains(t, string(b), "<name>64 Elizabeth Street, Melbourne, Victoria 3000, Australia</name>")
+	assert.Contains(t, string(b), `<Data name="postcode">`)
+	assert.Contains(t, string(b), "<coordinates>144.96328,-37.814107</coordinates>")
+
+	features, err := feature.ParseKML(b)
+	assert.NoError(t, err)
+	assert.Equal(t, []feature.Feature{melbourne, fiji}, features)
+}
+
+func TestParseKML(t *testing.T) {
+	features, err := feature.ParseKML([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>Eiffel Tower</name>
+        <address>Champ de Mars, 5 Avenue Anatole France, 75007 Paris, France</address>
+        <Point><coordinates> 2.2945,48.8583,0 </coordinates></Point>
+      </Placemark>
+      <Placemark>
+        <name>Route</name>
+        <LineString><coordinates>0,0 1,1</coordinates></LineString>
+      </Placemark>
+    </Folder>
+  </Document>
+</kml>`))
+	assert.NoError(t, err)
+	assert.Equal(t, []feature.Feature{{
+		Location: geo.Location{Lat: 48.8583, Lng: 2.2945},
+		Address:  &geo.Address{FormattedAddress: "Champ de Mars, 5 Avenue Anatole France, 75007 Paris, France"},
+	}}, features)
+
+	_, err = feature.ParseKML([]byte(`<kml><Document><Placemark><Point><coordinates>0</coordinates></Point></Placemark></Document></kml>`))
+	assert.Error(t, err)
+}
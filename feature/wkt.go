@@ -0,0 +1,204 @@
+package feature
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// WKB geometry types, and the flag of an SRID in PostGIS extended WKB
+const (
+	wkbPoint      = 1
+	wkbMultiPoint = 4
+	ewkbSRID      = 0x20000000
+)
+
+var (
+	wktPattern      = regexp.MustCompile(`(?i)^(?:SRID=(\d+);)?\s*(POINT|MULTIPOINT)\s*(?:Z|M|ZM)?\s*\((.*)\)$`)
+	wktPointPattern = regexp.MustCompile(`^\(?\s*([^\s()]+)\s+([^\s()]+)(?:\s+[^\s()]+){0,2}\s*\)?$`)
+)
+
+// WKT returns the Well-Known Text of locations, a POINT for one and a MULTIPOINT otherwise,
+// with longitude before latitude as in WGS84 databases
+func WKT(locations ...geo.Location) string {
+	if len(locations) == 1 {
+		return "POINT (" + wktCoordinates(locations[0]) + ")"
+	}
+	if len(locations) == 0 {
+		return "MULTIPOINT EMPTY"
+	}
+	points := make([]string, len(locations))
+	for i, l := range locations {
+		points[i] = "(" + wktCoordinates(l) + ")"
+	}
+	return "MULTIPOINT (" + strings.Join(points, ", ") + ")"
+}
+
+func wktCoordinates(l geo.Location) string {
+	return strconv.FormatFloat(l.Lng, 'f', -1, 64) + " " + strconv.FormatFloat(l.Lat, 'f', -1, 64)
+}
+
+// ParseWKT returns the locations of a WKT or PostGIS EWKT POINT or MULTIPOINT, in SRID 4326 if it has one
+func ParseWKT(s string) ([]geo.Location, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(strings.Join(strings.Fields(s), " "), "MULTIPOINT EMPTY") {
+		return nil, nil
+	}
+	m := wktPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported WKT %q", s)
+	}
+	if m[1] != "" && m[1] != "4326" {
+		return nil, fmt.Errorf("unsupported SRID %s", m[1])
+	}
+
+	points := []string{m[3]}
+	if strings.EqualFold(m[2], "MULTIPOINT") {
+		points = strings.Split(m[3], ",")
+	}
+	locations := make([]geo.Location, len(points))
+	for i, p := range points {
+		c := wktPointPattern.FindStringSubmatch(strings.TrimSpace(p))
+		if c == nil {
+			return nil, fmt.Errorf("invalid WKT point %q", p)
+		}
+		lng, err := strconv.ParseFloat(c[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WKT point %q: %v", p, err)
+		}
+		lat, err := strconv.ParseFloat(c[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WKT point %q: %v", p, err)
+		}
+		locations[i] = geo.Location{Lat: lat, Lng: lng}
+		if err := locations[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return locations, nil
+}
+
+// WKB returns the little endian Well-Known Binary of locations, a POINT for one and a MULTIPOINT otherwise
+func WKB(locations ...geo.Location) []byte {
+	var b bytes.Buffer
+	if len(locations) == 1 {
+		writeWKBPoint(&b, locations[0])
+		return b.Bytes()
+	}
+	b.WriteByte(1)
+	binary.Write(&b, binary.LittleEndian, uint32(wkbMultiPoint))
+	binary.Write(&b, binary.LittleEndian, uint32(len(locations)))
+	for _, l := range locations {
+		writeWKBPoint(&b, l)
+	}
+	return b.Bytes()
+}
+
+func writeWKBPoint(b *bytes.Buffer, l geo.Location) {
+	b.WriteByte(1)
+	binary.Write(b, binary.LittleEndian, uint32(wkbPoint))
+	binary.Write(b, binary.LittleEndian, [2]float64{l.Lng, l.Lat})
+}
+
+// wkbReader reads WKB in the byte order given at the start of each geometry
+type wkbReader struct {
+	data []byte
+	err  error
+}
+
+var errShortWKB = errors.New("WKB too short")
+
+func (r *wkbReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = errShortWKB
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+// header returns the byte order and type of the next geometry, skipping the SRID of extended WKB
+func (r *wkbReader) header() (binary.ByteOrder, uint32) {
+	var order binary.ByteOrder = binary.LittleEndian
+	b := r.next(5)
+	if r.err != nil {
+		return order, 0
+	}
+	switch b[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+	default:
+		r.err = fmt.Errorf("invalid WKB byte order %d", b[0])
+		return order, 0
+	}
+	t := order.Uint32(b[1:])
+	if t&ewkbSRID != 0 {
+		if srid := r.next(4); r.err == nil && order.Uint32(srid) != 4326 {
+			r.err = fmt.Errorf("unsupported SRID %d", order.Uint32(srid))
+		}
+		t &^= ewkbSRID
+	}
+	return order, t
+}
+
+func (r *wkbReader) point() geo.Location {
+	order, t := r.header()
+	if r.err == nil && t != wkbPoint {
+		r.err = fmt.Errorf("unsupported WKB geometry type %d", t)
+	}
+	b := r.next(16)
+	if r.err != nil {
+		return geo.Location{}
+	}
+	return geo.Location{
+		Lat: math.Float64frombits(order.Uint64(b[8:])),
+		Lng: math.Float64frombits(order.Uint64(b)),
+	}
+}
+
+// ParseWKB returns the locations of a WKB or PostGIS EWKB POINT or MULTIPOINT, in SRID 4326 if it has one
+func ParseWKB(data []byte) ([]geo.Location, error) {
+	r := &wkbReader{data: data}
+	var locations []geo.Location
+	order, t := r.header()
+	switch {
+	case r.err != nil:
+	case t == wkbPoint:
+		r.data = data
+		locations = append(locations, r.point())
+	case t == wkbMultiPoint:
+		var n uint32
+		if b := r.next(4); r.err == nil {
+			n = order.Uint32(b)
+		}
+		for i := uint32(0); i < n && r.err == nil; i++ {
+			locations = append(locations, r.point())
+		}
+	default:
+		r.err = fmt.Errorf("unsupported WKB geometry type %d", t)
+	}
+	if r.err == nil && len(r.data) > 0 {
+		r.err = fmt.Errorf("%d bytes after WKB geometry", len(r.data))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, l := range locations {
+		if err := l.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return locations, nil
+}
@@ -0,0 +1,74 @@
+package feature_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWKT(t *testing.T) {
+	assert.Equal(t, "POINT (144.96328 -37.814107)", feature.WKT(melbourne.Location))
+	assert.Equal(t, "MULTIPOINT ((144.96328 -37.814107), (178.065 -17.7134))", feature.WKT(melbourne.Location, fiji.Location))
+	assert.Equal(t, "MULTIPOINT EMPTY", feature.WKT())
+}
+
+func TestParseWKT(t *testing.T) {
+	for s, expected := range map[string][]geo.Location{
+		"POINT (144.96328 -37.814107)":                            {melbourne.Location},
+		"SRID=4326;point(144.96328 -37.814107)":                   {melbourne.Location},
+		"POINT Z (144.96328 -37.814107 10)":                       {melbourne.Location},
+		"MULTIPOINT ((144.96328 -37.814107), (178.065 -17.7134))": {melbourne.Location, fiji.Location},
+		"MULTIPOINT (144.96328 -37.814107, 178.065 -17.7134)":     {melbourne.Location, fiji.Location},
+		"MULTIPOINT EMPTY":                                        nil,
+	} {
+		locations, err := feature.ParseWKT(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, locations, s)
+	}
+
+	for _, s := range []string{"", "POINT EMPTY", "LINESTRING (0 0, 1 1)", "SRID=3857;POINT (0 0)", "POINT (a b)", "POINT (0 91)"} {
+		_, err := feature.ParseWKT(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestWKB(t *testing.T) {
+	assert.Equal(t, "0101000000000000000000f03f0000000000000040", hex.EncodeToString(feature.WKB(geo.Location{Lat: 2, Lng: 1})))
+
+	for _, locations := range [][]geo.Location{{melbourne.Location}, {melbourne.Location, fiji.Location}} {
+		parsed, err := feature.ParseWKB(feature.WKB(locations...))
+		assert.NoError(t, err)
+		assert.Equal(t, locations, parsed)
+	}
+}
+
+func TestParseWKB(t *testing.T) {
+	for s, expected := range map[string][]geo.Location{
+		// big endian
+		"00000000013ff00000000000004000000000000000": {{Lat: 2, Lng: 1}},
+		// PostGIS EWKB with SRID 4326
+		"0101000020e6100000000000000000f03f0000000000000040":                                                     {{Lat: 2, Lng: 1}},
+		"0104000000020000000101000000000000000000f03f0000000000000040010100000000000000000008400000000000001040": {{Lat: 2, Lng: 1}, {Lat: 4, Lng: 3}},
+	} {
+		b, _ := hex.DecodeString(s)
+		locations, err := feature.ParseWKB(b)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, locations, s)
+	}
+
+	for _, s := range []string{
+		"",
+		"0101000000000000000000f03f",
+		"0102000000000000000000f03f0000000000000040",
+		"0101000020110f0000000000000000f03f0000000000000040",
+		"0104000000020000000101000000000000000000f03f0000000000000040",
+		"0101000000000000000000f03f000000000000004000",
+	} {
+		b, _ := hex.DecodeString(s)
+		_, err := feature.ParseWKB(b)
+		assert.Error(t, err, s)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
 )
 
 type (
@@ -48,6 +49,16 @@ type (
 	}
 )
 
+func init() {
+	geo.Register("frenchapigouv", func(o geo.Options) (geo.Geocoder, error) {
+		g := Geocoder()
+		if o.BaseURL != "" {
+			g = GeocoderWithURL(o.BaseURL)
+		}
+		return o.Apply(g.(geo.HTTPGeocoder), "", ""), nil
+	})
+}
+
 // Geocoder constructs FrenchApiGouv geocoder
 func Geocoder() geo.Geocoder { return GeocoderWithURL("https://api-adresse.data.gouv.fr/") }
 
@@ -59,6 +70,34 @@ func GeocoderWithURL(url string) geo.Geocoder {
 	}
 }
 
+// coverage is metropolitan France and the overseas departments, whose addresses are in the Base Adresse Nationale
+var coverage = []geo.BoundingBox{
+	{South: 41.3, West: -5.2, North: 51.1, East: 9.6},
+	{South: 2.1, West: -54.6, North: 5.8, East: -51.6},
+	{South: 15.8, West: -61.9, North: 16.6, East: -61},
+	{South: 14.4, West: -61.3, North: 14.9, East: -60.8},
+	{South: -21.4, West: 55.2, North: -20.8, East: 55.9},
+	{South: -13.1, West: 44.9, North: -12.6, East: 45.3},
+}
+
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "frenchapigouv",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Countries:          []string{"FR", "GF", "GP", "MQ", "RE", "YT"},
+	Coverage:           coverage,
+	RateLimit:          50,
+	Terms: geo.Terms{
+		Attribution: "BAN",
+		Licence:     "etalab-2.0",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	return string(b) + "search?limit=1&q=" + address
 }
@@ -78,23 +117,27 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+// Licensing returns the attribution and licence of the data of the response
+func (r *geocodeResponse) Licensing() (string, string) { return r.Attribution, r.Licence }
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if len(r.Features) == 0 || r.Features[0].Properties.Label == "baninfo" {
 		return nil, nil
 	}
 	p := r.Features[0].Properties
 	c := r.parseContext()
-	return &geo.Address{
-		FormattedAddress: strings.Join(strings.Fields(strings.TrimSpace(fmt.Sprintf("%s, %s, %s, %s, %s, %s, %s", p.Housenumber, p.Street, p.Postcode, p.City, c.county, c.state, "France"))), " "),
-		HouseNumber:      p.Housenumber,
-		Street:           p.Street,
-		Postcode:         p.Postcode,
-		City:             p.City,
-		State:            c.state,
-		County:           c.county,
-		Country:          "France",
-		CountryCode:      "FRA",
-	}, nil
+	a := &geo.Address{
+		HouseNumber: p.Housenumber,
+		Street:      p.Street,
+		Postcode:    p.Postcode,
+		City:        p.City,
+		State:       c.state,
+		County:      c.county,
+		Country:     "France",
+		CountryCode: "FR",
+	}
+	a.FormattedAddress = address.FormatLine(*a)
+	return a, nil
 }
 
 func (r *geocodeResponse) parseContext() *context {
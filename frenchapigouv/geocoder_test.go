@@ -22,6 +22,16 @@ func TestGeocode(t *testing.T) {
 	assert.Equal(t, geo.Location{Lat: 48.859831, Lng: 2.328123}, *location)
 }
 
+func TestCapabilities(t *testing.T) {
+	c := geo.CapabilitiesOf(frenchapigouv.Geocoder())
+	assert.True(t, c.Serves("FR"))
+	assert.False(t, c.Serves("BE"))
+	assert.True(t, c.Covers(geo.Location{Lat: 48.8583701, Lng: 2.2944813}))
+	assert.True(t, c.Covers(geo.Location{Lat: -20.8789, Lng: 55.4481}))
+	assert.False(t, c.Covers(geo.Location{Lat: 40.4168, Lng: -3.7038}))
+	assert.Equal(t, "etalab-2.0", c.Licence)
+}
+
 func TestGeocodeWithNoResult(t *testing.T) {
 	ts := testServer(response2)
 	defer ts.Close()
@@ -39,9 +49,23 @@ func TestReverseGeocode(t *testing.T) {
 	geocoder := frenchapigouv.GeocoderWithURL(ts.URL + "/")
 	address, err := geocoder.ReverseGeocode(48.859831, 2.328123)
 	assert.Nil(t, err)
-	assert.True(t, strings.HasPrefix(address.FormattedAddress, "5, Quai Anatole France,"))
+	assert.Equal(t, "5 Quai Anatole France, 75007 Paris, France", address.FormattedAddress)
 	assert.Equal(t, "Île-de-France", address.State)
 	assert.Equal(t, "Paris", address.County)
+	assert.Equal(t, "FR", address.CountryCode)
+	// the attribution and licence of the response replace those of the capabilities
+	assert.Equal(t, "frenchapigouv", address.Source.Provider)
+	assert.Equal(t, geo.Terms{Attribution: "BAN", Licence: "ODbL 1.0"}, address.Source.Terms)
+}
+
+func TestReverseGeocodeStreet(t *testing.T) {
+	ts := testServer(strings.Replace(response1, `"housenumber": "5",`, "", 1))
+	defer ts.Close()
+
+	geocoder := frenchapigouv.GeocoderWithURL(ts.URL + "/")
+	address, err := geocoder.ReverseGeocode(48.859831, 2.328123)
+	assert.Nil(t, err)
+	assert.Equal(t, "Quai Anatole France, 75007 Paris, France", address.FormattedAddress)
 }
 
 func TestReverseGeocodeWithNoResult(t *testing.T) {
@@ -1,39 +1,101 @@
 package geocod
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	geo "github.com/codingsince1985/geo-golang"
 )
 
 type (
-	baseURL         string
+	baseURL struct {
+		url    string
+		fields string
+	}
 	geocodeResponse struct {
+		Results []result
+		Error   string
+	}
+	batchResponse struct {
 		Results []struct {
-			Components struct {
-				Number  string
-				Street  string
-				City    string
-				County  string
-				State   string
-				Zip     string
-				Country string
-			} `json:"address_components"`
-			Address  string `json:"formatted_address"`
-			Location struct {
-				Lat float64
-				Lng float64
-			}
+			Query    string
+			Response geocodeResponse
 		}
+		Error string
 	}
+	result struct {
+		Components struct {
+			Number          string
+			Street          string
+			FormattedStreet string `json:"formatted_street"`
+			City            string
+			County          string
+			State           string
+			Zip             string
+			Country         string
+		} `json:"address_components"`
+		Address  string `json:"formatted_address"`
+		Location struct {
+			Lat float64
+			Lng float64
+		}
+		Accuracy     float64
+		AccuracyType string `json:"accuracy_type"`
+		Fields       Fields
+	}
+)
+
+// maxBatchSize is the maximum number of addresses or locations in a batch request
+const maxBatchSize = 10000
+
+// Field* are the appends Geocodio can add to its results
+const (
+	FieldTimezone                  = "timezone"
+	FieldCongressionalDistrict     = "cd"
+	FieldStateLegislativeDistricts = "stateleg"
+	FieldCensus                    = "census"
+	FieldSchoolDistricts           = "school"
 )
 
+// Geocodio is a geo.BatchGeocoder that can also return its matches with their accuracy and appended fields
+type Geocodio struct {
+	geo.HTTPGeocoder
+	url baseURL
+}
+
+func init() {
+	geo.Register("geocodio", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		var fields []string
+		if f := o.Extra["fields"]; f != "" {
+			fields = strings.Split(f, ",")
+		}
+		g := GeocoderWithFields(o.Key, fields, o.BaseURLs()...)
+		g.HTTPGeocoder = o.Apply(g.HTTPGeocoder, "", "")
+		return g, nil
+	})
+}
+
 // Geocoder constructs Geocodio geocoder
 func Geocoder(key string, baseURLs ...string) geo.Geocoder {
-	return geo.HTTPGeocoder{
-		EndpointBuilder:       baseURL(getUrl(key, baseURLs...)),
-		ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
+	return GeocoderWithFields(key, nil, baseURLs...)
+}
+
+// GeocoderWithFields constructs Geocodio geocoder appending fields, such as FieldTimezone or FieldCensus, to its results
+func GeocoderWithFields(key string, fields []string, baseURLs ...string) Geocodio {
+	b := baseURL{url: getUrl(key, baseURLs...), fields: strings.Join(fields, ",")}
+	return Geocodio{
+		HTTPGeocoder: geo.HTTPGeocoder{
+			EndpointBuilder:       b,
+			ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
+		},
+		url: b,
 	}
 }
 
@@ -44,19 +106,46 @@ func getUrl(key string, baseURLs ...string) string {
 	return "https://api.geocod.io/v1/*&api_key=" + key
 }
 
+func (b baseURL) endpoint(path, query string) string {
+	if b.fields != "" {
+		if query != "" {
+			query += "&"
+		}
+		query += "fields=" + b.fields
+	}
+	return strings.Replace(b.url, "*", path+"?"+query, 1)
+}
+
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "geocodio",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	MultipleResults:    true,
+	Countries:          []string{"US", "CA"},
+	MaxBatchSize:       maxBatchSize,
+	Terms: geo.Terms{
+		Licence: "Geocodio Terms of Service",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
-	params := fmt.Sprintf("geocode?q=%s", address)
-	url := strings.Replace(string(b), "*", params, 1)
-	return url
+	return b.endpoint("geocode", fmt.Sprintf("q=%s", address))
 }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
-	params := fmt.Sprintf("reverse?q=%f,%f", l.Lat, l.Lng)
-	url := strings.Replace(string(b), "*", params, 1)
-	return url
+	return b.endpoint("reverse", fmt.Sprintf("q=%f,%f", l.Lat, l.Lng))
 }
 
 func (r *geocodeResponse) Location() (*geo.Location, error) {
+	if r.Error != "" {
+		return nil, fmt.Errorf("geocoding error: %s", r.Error)
+	}
 	if len(r.Results) == 0 {
 		return nil, nil
 	}
@@ -66,20 +155,174 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 }
 
 func (r *geocodeResponse) Address() (*geo.Address, error) {
+	if r.Error != "" {
+		return nil, fmt.Errorf("reverse geocoding error: %s", r.Error)
+	}
 	if len(r.Results) == 0 {
 		return nil, nil
 	}
 
-	r0 := r.Results[0]
-	c := r0.Components
+	return r.Results[0].address(), nil
+}
+
+func (r *geocodeResponse) Timezone() string {
+	if len(r.Results) == 0 || r.Results[0].Fields.Timezone == nil {
+		return ""
+	}
+	return r.Results[0].Fields.Timezone.Name
+}
+
+func (r result) address() *geo.Address {
+	c := r.Components
+	street := c.FormattedStreet
+	if street == "" {
+		street = c.Street
+	}
 	addr := &geo.Address{
-		FormattedAddress: r0.Address,
-		Street:           c.Street,
+		FormattedAddress: r.Address,
+		Street:           street,
 		HouseNumber:      c.Number,
 		Postcode:         c.Zip,
+		City:             c.City,
 		State:            c.State,
+		County:           c.County,
 		CountryCode:      c.Country,
 	}
+	if r.Fields.Timezone != nil {
+		addr.Timezone = r.Fields.Timezone.Name
+	}
+	addr.NormalizeCountry()
+	return addr
+}
+
+func (r *geocodeResponse) results() []Result {
+	if r.Error != "" || len(r.Results) == 0 {
+		return nil
+	}
+	results := make([]Result, len(r.Results))
+	for i, res := range r.Results {
+		results[i] = Result{
+			Location:     geo.Location{Lat: res.Location.Lat, Lng: res.Location.Lng},
+			Address:      *res.address(),
+			Accuracy:     res.Accuracy,
+			AccuracyType: res.AccuracyType,
+			Fields:       res.Fields,
+		}
+	}
+	return results
+}
+
+// MaxBatchSize returns the maximum number of queries in a batch request
+func (g Geocodio) MaxBatchSize() int { return maxBatchSize }
+
+// BatchGeocode returns locations for addresses
+func (g Geocodio) BatchGeocode(addresses []string) ([]*geo.Location, error) {
+	results, err := g.BatchLookup(addresses)
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]*geo.Location, len(results))
+	for i := range results {
+		if len(results[i]) > 0 {
+			locations[i] = &results[i][0].Location
+		}
+	}
+	return locations, nil
+}
+
+// BatchReverseGeocode returns addresses for locations
+func (g Geocodio) BatchReverseGeocode(locations []geo.Location) ([]*geo.Address, error) {
+	results, err := g.BatchReverseLookup(locations)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]*geo.Address, len(results))
+	for i := range results {
+		if len(results[i]) > 0 {
+			addresses[i] = &results[i][0].Address
+		}
+	}
+	return addresses, nil
+}
+
+// Lookup returns the matches for address, best first
+func (g Geocodio) Lookup(address string) ([]Result, error) {
+	r, err := g.get(g.url.GeocodeURL(url.QueryEscape(address)))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Location(); err != nil {
+		return nil, err
+	}
+	return r.results(), nil
+}
+
+// ReverseLookup returns the matches for location, nearest first
+func (g Geocodio) ReverseLookup(lat, lng float64) ([]Result, error) {
+	r, err := g.get(g.url.ReverseGeocodeURL(geo.Location{Lat: lat, Lng: lng}))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Address(); err != nil {
+		return nil, err
+	}
+	return r.results(), nil
+}
 
-	return addr, nil
+// BatchLookup returns the matches for each address, nil for those Geocodio could not geocode
+func (g Geocodio) BatchLookup(addresses []string) ([][]Result, error) {
+	return g.batch("geocode", addresses)
+}
+
+// BatchReverseLookup returns the matches for each location, nil for those Geocodio could not reverse geocode
+func (g Geocodio) BatchReverseLookup(locations []geo.Location) ([][]Result, error) {
+	queries := make([]string, len(locations))
+	for i, l := range locations {
+		queries[i] = fmt.Sprintf("%f,%f", l.Lat, l.Lng)
+	}
+	return g.batch("reverse", queries)
+}
+
+func (g Geocodio) get(endpoint string) (*geocodeResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	var r geocodeResponse
+	if err := g.Do(req, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (g Geocodio) batch(path string, queries []string) ([][]Result, error) {
+	if len(queries) > maxBatchSize {
+		return nil, fmt.Errorf("batch of %d queries exceeds %d", len(queries), maxBatchSize)
+	}
+	body, err := json.Marshal(queries)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, g.url.endpoint(path, ""), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var r batchResponse
+	if err := g.Do(req, &r); err != nil {
+		return nil, err
+	}
+	if r.Error != "" {
+		return nil, fmt.Errorf("batch geocoding error: %s", r.Error)
+	}
+	if len(r.Results) != len(queries) {
+		return nil, fmt.Errorf("batch geocoding error: %d results for %d queries", len(r.Results), len(queries))
+	}
+
+	results := make([][]Result, len(r.Results))
+	for i := range r.Results {
+		results[i] = r.Results[i].Response.results()
+	}
+	return results, nil
 }
@@ -1,6 +1,8 @@
 package geocod
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"math"
 	"net/http"
 	"net/http/httptest"
@@ -59,6 +61,91 @@ func TestReverseGeocode(t *testing.T) {
 	if addr.State != state {
 		t.Fatalf("Got: %v\tExpected: %v\n", addr.State, state)
 	}
+	if addr.City != "Washington" || addr.Street != "H St NE" {
+		t.Fatalf("Got: %v, %v\tExpected: Washington, H St NE\n", addr.City, addr.Street)
+	}
+}
+
+func TestLookupWithFields(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		resp.Write([]byte(fieldsResp))
+	}))
+	defer ts.Close()
+
+	geocoder := GeocoderWithFields(key, []string{FieldTimezone, FieldCongressionalDistrict, FieldCensus}, ts.URL+"/*")
+	results, err := geocoder.Lookup("1109 N Highland St, Arlington VA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "q=1109+N+Highland+St%2C+Arlington+VA&fields=timezone,cd,census" {
+		t.Fatalf("Got: %v\tExpected fields in query\n", query)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Got: %d results\tExpected: 1\n", len(results))
+	}
+
+	r := results[0]
+	if r.Accuracy != 1 || r.AccuracyType != "rooftop" {
+		t.Fatalf("Got: %v %v\tExpected: 1 rooftop\n", r.Accuracy, r.AccuracyType)
+	}
+	f := r.Fields
+	if f.Timezone == nil || f.Timezone.Name != "America/New_York" || f.Timezone.UTCOffset != -5 {
+		t.Fatalf("Got: %+v\tExpected: America/New_York\n", f.Timezone)
+	}
+	if r.Address.Timezone != "America/New_York" {
+		t.Fatalf("Got: %v\tExpected: America/New_York\n", r.Address.Timezone)
+	}
+	if len(f.CongressionalDistricts) != 1 || f.CongressionalDistricts[0].DistrictNumber != 8 {
+		t.Fatalf("Got: %+v\tExpected: district 8\n", f.CongressionalDistricts)
+	}
+	if f.Census["2010"].CountyFIPS != "51013" {
+		t.Fatalf("Got: %+v\tExpected: county 51013\n", f.Census)
+	}
+	if _, ok := f.Other["acs-demographics"]; !ok {
+		t.Fatalf("Got: %v\tExpected: acs-demographics kept raw\n", f.Other)
+	}
+}
+
+func TestBatchGeocode(t *testing.T) {
+	var (
+		method  string
+		queries []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		method = req.Method
+		body, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(body, &queries)
+		resp.Write([]byte(batchResp))
+	}))
+	defer ts.Close()
+
+	addresses := []string{"1109 N Highland St, Arlington VA", "nowhere"}
+	geocoder := Geocoder(key, ts.URL).(geo.BatchGeocoder)
+	locs, err := geocoder.BatchGeocode(addresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPost || len(queries) != 2 || queries[0] != addresses[0] {
+		t.Fatalf("Got: %s %v\tExpected: POST %v\n", method, queries, addresses)
+	}
+	if len(locs) != 2 || locs[1] != nil {
+		t.Fatalf("Got: %v\tExpected: 1 location and 1 nil\n", locs)
+	}
+	if math.Abs(locs[0].Lat-38.886665) > eps || math.Abs(locs[0].Lng+77.094733) > eps {
+		t.Fatalf("Got: %v\tExpected: 38.886665,-77.094733\n", locs[0])
+	}
+}
+
+func TestGeocodeError(t *testing.T) {
+	ts := testServer(`{"error": "Could not geocode address. Postal code or city required."}`)
+	defer ts.Close()
+
+	loc, err := Geocoder(key, ts.URL).Geocode("Highland St")
+	if err == nil || loc != nil {
+		t.Fatalf("Got: %v %v\tExpected: error\n", loc, err)
+	}
 }
 
 func testServer(response string) *httptest.Server {
@@ -183,4 +270,87 @@ const (
     }
   ]
 }`
+
+	fieldsResp = `
+{
+  "results": [
+    {
+      "address_components": {
+        "number": "1109",
+        "street": "Highland",
+        "city": "Arlington",
+        "state": "VA",
+        "zip": "22201",
+        "country": "US"
+      },
+      "formatted_address": "1109 N Highland St, Arlington, VA 22201",
+      "location": {
+        "lat": 38.886665,
+        "lng": -77.094733
+      },
+      "accuracy": 1,
+      "accuracy_type": "rooftop",
+      "source": "Virginia GIS Clearinghouse",
+      "fields": {
+        "timezone": {
+          "name": "America/New_York",
+          "utc_offset": -5,
+          "observes_dst": true,
+          "abbreviation": "EST",
+          "source": "© OpenStreetMap contributors"
+        },
+        "congressional_districts": [
+          {
+            "name": "Congressional District 8",
+            "district_number": 8,
+            "congress_number": "116th",
+            "congress_years": "2019-2021",
+            "proportion": 1
+          }
+        ],
+        "census": {
+          "2010": {
+            "census_year": 2010,
+            "state_fips": "51",
+            "county_fips": "51013",
+            "tract_code": "101801",
+            "block_code": "1004",
+            "block_group": "1",
+            "full_fips": "510131018011004",
+            "source": "US Census Bureau"
+          }
+        },
+        "acs-demographics": {
+          "meta": {"source": "American Community Survey from the US Census Bureau"}
+        }
+      }
+    }
+  ]
+}`
+
+	batchResp = `
+{
+  "results": [
+    {
+      "query": "1109 N Highland St, Arlington VA",
+      "response": {
+        "results": [
+          {
+            "address_components": {"number": "1109", "street": "Highland", "state": "VA", "zip": "22201", "country": "US"},
+            "formatted_address": "1109 N Highland St, Arlington, VA 22201",
+            "location": {"lat": 38.886665, "lng": -77.094733},
+            "accuracy": 1,
+            "accuracy_type": "rooftop"
+          }
+        ]
+      }
+    },
+    {
+      "query": "nowhere",
+      "response": {
+        "error": "Could not geocode address. Postal code or city required."
+      }
+    }
+  ]
+}`
 )
@@ -0,0 +1,102 @@
+package geocod
+
+import (
+	"encoding/json"
+
+	geo "github.com/codingsince1985/geo-golang"
+)
+
+type (
+	// Result is a Geocodio match with its accuracy and the fields appended to it
+	Result struct {
+		Location     geo.Location
+		Address      geo.Address
+		Accuracy     float64
+		AccuracyType string
+		Fields       Fields
+	}
+
+	// Fields appended to a match. Those without a dedicated type are kept in Other as raw JSON, by name
+	Fields struct {
+		Timezone                  *Timezone
+		CongressionalDistricts    []CongressionalDistrict
+		StateLegislativeDistricts map[string][]LegislativeDistrict // by chamber: house or senate
+		Census                    map[string]Census                // by census year
+		SchoolDistricts           map[string]SchoolDistrict        // by type: unified, elementary or secondary
+		Other                     map[string]json.RawMessage
+	}
+
+	// Timezone appended by FieldTimezone
+	Timezone struct {
+		Name         string
+		Abbreviation string
+		UTCOffset    int  `json:"utc_offset"`
+		ObservesDST  bool `json:"observes_dst"`
+		Source       string
+	}
+
+	// CongressionalDistrict appended by FieldCongressionalDistrict
+	CongressionalDistrict struct {
+		Name           string
+		DistrictNumber int    `json:"district_number"`
+		CongressNumber string `json:"congress_number"`
+		CongressYears  string `json:"congress_years"`
+		Proportion     float64
+	}
+
+	// LegislativeDistrict appended by FieldStateLegislativeDistricts
+	LegislativeDistrict struct {
+		Name           string
+		DistrictNumber string `json:"district_number"`
+		Proportion     float64
+	}
+
+	// Census appended by FieldCensus
+	Census struct {
+		CensusYear int    `json:"census_year"`
+		StateFIPS  string `json:"state_fips"`
+		CountyFIPS string `json:"county_fips"`
+		TractCode  string `json:"tract_code"`
+		BlockCode  string `json:"block_code"`
+		BlockGroup string `json:"block_group"`
+		FullFIPS   string `json:"full_fips"`
+		Source     string
+	}
+
+	// SchoolDistrict appended by FieldSchoolDistricts
+	SchoolDistrict struct {
+		Name      string
+		LEACode   string `json:"lea_code"`
+		GradeLow  string `json:"grade_low"`
+		GradeHigh string `json:"grade_high"`
+	}
+)
+
+// UnmarshalJSON decodes the known fields, keeping the others raw
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	known := map[string]interface{}{
+		"timezone":                    &f.Timezone,
+		"congressional_districts":     &f.CongressionalDistricts,
+		"state_legislative_districts": &f.StateLegislativeDistricts,
+		"census":                      &f.Census,
+		"school_districts":            &f.SchoolDistricts,
+	}
+	for name, raw := range fields {
+		if v, ok := known[name]; ok {
+			if err := json.Unmarshal(raw, v); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.Other == nil {
+			f.Other = map[string]json.RawMessage{}
+		}
+		f.Other[name] = raw
+	}
+	return nil
+}
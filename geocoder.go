@@ -12,13 +12,42 @@ type Geocoder interface {
 	ReverseGeocode(lat, lng float64) (*Address, error)
 }
 
+// BatchGeocoder is a Geocoder whose service can look up many addresses or locations in one request.
+// Results are in the same order as the input, with nil for those not found
+type BatchGeocoder interface {
+	Geocoder
+	BatchGeocode(addresses []string) ([]*Location, error)
+	BatchReverseGeocode(locations []Location) ([]*Address, error)
+	MaxBatchSize() int
+}
+
+// ResultGeocoder is a Geocoder which can also return what its service tells about the location of an address
+type ResultGeocoder interface {
+	Geocoder
+	GeocodeResult(address string) (*Result, error)
+}
+
+// Result is the output of GeocodeResult.
+// BoundingBox is the extent of the place found, or nil if the service does not supply one.
+// Timezone is the IANA name of the timezone of the place, or "" if unknown.
+// Address is the address of the place found, or nil if the service does not supply it with the location.
+// Source is the provider of the result and its terms, or nil if unknown
+type Result struct {
+	Location    Location
+	BoundingBox *BoundingBox
+	Timezone    string
+	Address     *Address
+	Source      *Source
+}
+
 // Location is the output of Geocode
 type Location struct {
 	Lat, Lng float64
 }
 
 // Address is returned by ReverseGeocode.
-// This is a structured representation of an address, including its flat representation
+// This is a structured representation of an address, including its flat representation,
+// the IANA name of its timezone if known, and its source if known
 type Address struct {
 	FormattedAddress string
 	Street           string
@@ -31,6 +60,8 @@ type Address struct {
 	Country          string
 	CountryCode      string
 	City             string
+	Timezone         string
+	Source           *Source
 }
 
 // Logger is an implementation of StdLogger that geo uses to log its messages.
@@ -0,0 +1,234 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WGS84 ellipsoid, in meters
+const (
+	EquatorialRadius = 6378137.0
+	Flattening       = 1 / 298.257223563
+	PolarRadius      = EquatorialRadius * (1 - Flattening)
+	MeanRadius       = (2*EquatorialRadius + PolarRadius) / 3
+)
+
+const (
+	vincentyEpsilon       = 1e-12
+	vincentyMaxIterations = 200
+)
+
+// Geodesic is the shortest path between two locations on the WGS84 ellipsoid.
+// Distance is in meters and bearings are in degrees clockwise from north, in [0, 360)
+type Geodesic struct {
+	Distance       float64
+	InitialBearing float64
+	FinalBearing   float64
+}
+
+// ErrNoConvergence occurs when Vincenty's formulae fail to converge, which happens only for antipodal
+// and nearly antipodal locations
+var ErrNoConvergence = errors.New("vincenty formulae failed to converge")
+
+// Inverse solves the inverse geodesic problem between from and to with Vincenty's formulae,
+// accurate to within 0.5mm on the WGS84 ellipsoid
+func Inverse(from, to Location) (Geodesic, error) {
+	phi1, phi2 := radians(from.Lat), radians(to.Lat)
+	l := radians(to.Lng - from.Lng)
+
+	tanU1 := (1 - Flattening) * math.Tan(phi1)
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	tanU2 := (1 - Flattening) * math.Tan(phi2)
+	cosU2 := 1 / math.Sqrt(1+tanU2*tanU2)
+	sinU2 := tanU2 * cosU2
+
+	lambda := l
+	var sinLambda, cosLambda, sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == vincentyMaxIterations {
+			return Geodesic{}, ErrNoConvergence
+		}
+		sinLambda, cosLambda = math.Sin(lambda), math.Cos(lambda)
+		sinSqSigma := (cosU2*sinLambda)*(cosU2*sinLambda) +
+			(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda)
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		if sinSqSigma < vincentyEpsilon*vincentyEpsilon {
+			if cosSigma > 0 {
+				// coincident locations
+				return Geodesic{}, nil
+			}
+			// antipodal locations, joined by any meridian
+			return Geodesic{}, ErrNoConvergence
+		}
+		sinSigma = math.Sqrt(sinSqSigma)
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0 // on the equator
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		c := Flattening / 16 * cosSqAlpha * (4 + Flattening*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*Flattening*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda) > math.Pi+vincentyEpsilon {
+			return Geodesic{}, ErrNoConvergence
+		}
+		if math.Abs(lambda-prev) < vincentyEpsilon {
+			break
+		}
+	}
+
+	uSq := cosSqAlpha * (EquatorialRadius*EquatorialRadius - PolarRadius*PolarRadius) / (PolarRadius * PolarRadius)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	alpha1 := math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	alpha2 := math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+	return Geodesic{
+		Distance:       PolarRadius * a * (sigma - deltaSigma),
+		InitialBearing: bearing(alpha1),
+		FinalBearing:   bearing(alpha2),
+	}, nil
+}
+
+// geodesic returns the geodesic from l to to, with Karney's algorithm for the nearly antipodal locations
+// Vincenty's formulae cannot solve
+func (l Location) geodesic(to Location) Geodesic {
+	if g, err := Inverse(l, to); err == nil {
+		return g
+	}
+	return wgs84.inverse(l, to)
+}
+
+// Distance returns the geodesic distance in meters from l to to on the WGS84 ellipsoid
+func (l Location) Distance(to Location) float64 { return l.geodesic(to).Distance }
+
+// InitialBearing returns the bearing in degrees to follow from l along the geodesic to to
+func (l Location) InitialBearing(to Location) float64 { return l.geodesic(to).InitialBearing }
+
+// FinalBearing returns the bearing in degrees arriving at to along the geodesic from l
+func (l Location) FinalBearing(to Location) float64 { return l.geodesic(to).FinalBearing }
+
+// Destination returns the location reached from l after distance meters along the geodesic starting at bearing degrees,
+// with the bearing on arrival
+func (l Location) Destination(distance, bearingDegrees float64) (Location, float64) {
+	alpha1 := radians(bearingDegrees)
+	sinAlpha1, cosAlpha1 := math.Sin(alpha1), math.Cos(alpha1)
+
+	tanU1 := (1 - Flattening) * math.Tan(radians(l.Lat))
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cosSqAlpha := 1 - sinAlpha*sinAlpha
+	uSq := cosSqAlpha * (EquatorialRadius*EquatorialRadius - PolarRadius*PolarRadius) / (PolarRadius * PolarRadius)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+	sigma := distance / (PolarRadius * a)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < vincentyMaxIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sin(sigma), math.Cos(sigma)
+		deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		prev := sigma
+		sigma = distance/(PolarRadius*a) + deltaSigma
+		if math.Abs(sigma-prev) < vincentyEpsilon {
+			break
+		}
+	}
+	sinSigma, cosSigma = math.Sin(sigma), math.Cos(sigma)
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	phi2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-Flattening)*math.Sqrt(sinAlpha*sinAlpha+x*x))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	c := Flattening / 16 * cosSqAlpha * (4 + Flattening*(4-3*cosSqAlpha))
+	lng := lambda - (1-c)*Flattening*sinAlpha*
+		(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	return Location{Lat: degrees(phi2), Lng: WrapLongitude(l.Lng + degrees(lng))}, bearing(math.Atan2(sinAlpha, -x))
+}
+
+// Midpoint returns the location halfway along the geodesic from l to to
+func (l Location) Midpoint(to Location) Location {
+	g := l.geodesic(to)
+	m, _ := l.Destination(g.Distance/2, g.InitialBearing)
+	return m
+}
+
+// CrossTrackDistance returns the distance in meters from l to the great circle through start and end,
+// on a sphere of MeanRadius. It is negative when l is left of the path from start to end
+func (l Location) CrossTrackDistance(start, end Location) float64 {
+	delta13 := start.sphericalDistance(l) / MeanRadius
+	theta13 := radians(start.sphericalBearing(l))
+	theta12 := radians(start.sphericalBearing(end))
+	return math.Asin(math.Sin(delta13)*math.Sin(theta13-theta12)) * MeanRadius
+}
+
+// sphericalDistance returns the great circle distance in meters from l to to with the haversine formula
+func (l Location) sphericalDistance(to Location) float64 {
+	phi1, phi2 := radians(l.Lat), radians(to.Lat)
+	dPhi, dLambda := phi2-phi1, radians(to.Lng-l.Lng)
+	h := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * MeanRadius * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+}
+
+// sphericalBearing returns the initial bearing in degrees of the great circle from l to to
+func (l Location) sphericalBearing(to Location) float64 {
+	phi1, phi2 := radians(l.Lat), radians(to.Lat)
+	dLambda := radians(to.Lng - l.Lng)
+	return bearing(math.Atan2(math.Sin(dLambda)*math.Cos(phi2),
+		math.Cos(phi1)*math.Sin(phi2)-math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)))
+}
+
+// Valid tells whether l has a latitude in [-90, 90] and a longitude in [-180, 180]
+func (l Location) Valid() bool { return l.Validate() == nil }
+
+// Validate returns an error if the latitude of l is outside [-90, 90] or its longitude is outside [-180, 180]
+func (l Location) Validate() error {
+	if math.IsNaN(l.Lat) || l.Lat < -90 || l.Lat > 90 {
+		return fmt.Errorf("invalid latitude %v", l.Lat)
+	}
+	if math.IsNaN(l.Lng) || l.Lng < -180 || l.Lng > 180 {
+		return fmt.Errorf("invalid longitude %v", l.Lng)
+	}
+	return nil
+}
+
+// Normalize returns l with its latitude clamped to [-90, 90] and its longitude wrapped to [-180, 180)
+func (l Location) Normalize() Location {
+	return Location{Lat: ClampLatitude(l.Lat), Lng: WrapLongitude(l.Lng)}
+}
+
+// ClampLatitude limits lat to [-90, 90]
+func ClampLatitude(lat float64) float64 { return math.Max(-90, math.Min(90, lat)) }
+
+// WrapLongitude wraps lng around the antimeridian to [-180, 180)
+func WrapLongitude(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+func radians(d float64) float64 { return d * math.Pi / 180 }
+
+func degrees(r float64) float64 { return r * 180 / math.Pi }
+
+// bearing converts r radians to degrees in [0, 360)
+func bearing(r float64) float64 {
+	d := math.Mod(degrees(r)+360, 360)
+	if d == 360 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,94 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func dms(d, m, s float64) float64 { return math.Copysign(math.Abs(d)+m/60+s/3600, d) }
+
+// Flinders Peak and Buninyong, the worked example of Geoscience Australia for Vincenty's formulae
+var (
+	flindersPeak = geo.Location{Lat: dms(-37, 57, 3.72030), Lng: dms(144, 25, 29.52440)}
+	buninyong    = geo.Location{Lat: dms(-37, 39, 10.15610), Lng: dms(143, 55, 35.38390)}
+)
+
+func TestInverse(t *testing.T) {
+	g, err := geo.Inverse(flindersPeak, buninyong)
+	assert.NoError(t, err)
+	assert.InDelta(t, 54972.271, g.Distance, 0.001)
+	assert.InDelta(t, dms(306, 52, 5.37), g.InitialBearing, 0.01/3600)
+	assert.InDelta(t, dms(127, 10, 25.07)+180, g.FinalBearing, 0.01/3600)
+
+	assert.InDelta(t, 10001965.729, geo.Location{}.Distance(geo.Location{Lat: 90}), 0.001)
+	assert.InDelta(t, 111319.491, geo.Location{}.Distance(geo.Location{Lng: 1}), 0.001)
+	assert.Equal(t, 0.0, flindersPeak.Distance(flindersPeak))
+}
+
+func TestInverseAntipodal(t *testing.T) {
+	from, to := geo.Location{}, geo.Location{Lat: 0.5, Lng: 179.7}
+	_, err := geo.Inverse(from, to)
+	assert.Equal(t, geo.ErrNoConvergence, err)
+	assert.InDelta(t, 19944127.421, from.Distance(to), 0.001)
+	assert.InDelta(t, 19944127.421, to.Distance(from), 0.001)
+	assert.InDelta(t, 15.556883, from.InitialBearing(to), 1e-6)
+
+	// the example of Karney, Algorithms for geodesics (2013)
+	from, to = geo.Location{Lat: -30}, geo.Location{Lat: 29.9, Lng: 179.8}
+	assert.InDelta(t, 19989832.828, from.Distance(to), 0.001)
+	assert.InDelta(t, 161.890524, from.InitialBearing(to), 1e-6)
+	assert.InDelta(t, 18.090737, from.FinalBearing(to), 1e-6)
+}
+
+func TestInverseExactlyAntipodal(t *testing.T) {
+	// antipodal locations are joined through the poles, along half a meridian
+	const halfMeridian = 20003931.459
+	for _, c := range [][2]geo.Location{
+		{{}, {Lng: 180}},
+		{{Lat: 90}, {Lat: -90}},
+		{{Lat: 10, Lng: 20}, {Lat: -10, Lng: -160}},
+		{{Lat: 45}, {Lat: -45, Lng: 180}},
+	} {
+		from, to := c[0], c[1]
+		_, err := geo.Inverse(from, to)
+		assert.Equal(t, geo.ErrNoConvergence, err, "%v %v", from, to)
+		assert.InDelta(t, halfMeridian, from.Distance(to), 0.001, "%v %v", from, to)
+		assert.InDelta(t, halfMeridian/2, from.Midpoint(to).Distance(from), 0.001, "%v %v", from, to)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	to, finalBearing := flindersPeak.Destination(54972.271, dms(306, 52, 5.37))
+	assert.InDelta(t, buninyong.Lat, to.Lat, 1e-8)
+	assert.InDelta(t, buninyong.Lng, to.Lng, 1e-8)
+	assert.InDelta(t, dms(127, 10, 25.07)+180, finalBearing, 0.01/3600)
+
+	to, _ = geo.Location{Lng: 179.5}.Destination(111319.491, 90)
+	assert.InDelta(t, -179.5, to.Lng, 1e-8)
+}
+
+func TestMidpoint(t *testing.T) {
+	m := flindersPeak.Midpoint(buninyong)
+	assert.InDelta(t, m.Distance(flindersPeak), m.Distance(buninyong), 0.001)
+	assert.InDelta(t, 54972.271/2, m.Distance(buninyong), 0.001)
+}
+
+func TestCrossTrackDistance(t *testing.T) {
+	l := geo.Location{Lat: 53.2611, Lng: -0.7972}
+	d := l.CrossTrackDistance(geo.Location{Lat: 53.3206, Lng: -1.7297}, geo.Location{Lat: 53.1887, Lng: 0.1334})
+	assert.InDelta(t, -307.5, d, 0.1)
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, geo.Location{Lat: 90, Lng: -170}, geo.Location{Lat: 91, Lng: 190}.Normalize())
+	assert.Equal(t, geo.Location{Lat: -90, Lng: 170}, geo.Location{Lat: -95, Lng: -550}.Normalize())
+	assert.Equal(t, -180.0, geo.WrapLongitude(180))
+	assert.Equal(t, 0.0, geo.WrapLongitude(720))
+
+	assert.True(t, geo.Location{Lat: -90, Lng: 180}.Valid())
+	assert.Error(t, geo.Location{Lat: 90.5}.Validate())
+	assert.Error(t, geo.Location{Lng: math.NaN()}.Validate())
+}
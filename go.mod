@@ -5,4 +5,5 @@ go 1.12
 require (
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/stretchr/testify v1.3.0
+	gopkg.in/yaml.v2 v2.4.0
 )
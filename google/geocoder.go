@@ -4,6 +4,7 @@ package google
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/codingsince1985/geo-golang"
 )
@@ -16,6 +17,9 @@ type (
 			AddressComponents []googleAddressComponent `json:"address_components"`
 			Geometry          struct {
 				Location geo.Location
+				Viewport *struct {
+					Northeast, Southwest geo.Location
+				}
 			}
 		}
 		Status string `json:"status"`
@@ -40,6 +44,15 @@ const (
 	componentTypePostcode      = "postal_code"
 )
 
+func init() {
+	geo.Register("google", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "language", "region"), nil
+	})
+}
+
 // Geocoder constructs Google geocoder
 func Geocoder(apiKey string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -55,6 +68,28 @@ func getURL(apiKey string, baseURLs ...string) string {
 	return fmt.Sprintf("https://maps.googleapis.com/maps/api/geocode/json?key=%s&", apiKey)
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "google",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	RateLimit:          50,
+	// latitudes and longitudes may be cached for up to 30 days
+	Terms: geo.Terms{
+		Attribution: "Google",
+		Licence:     "Google Maps Platform Terms of Service",
+		MaxAge:      30 * 24 * time.Hour,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string { return string(b) + "address=" + address }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
@@ -71,6 +106,14 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	return &r.Results[0].Geometry.Location, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if r.Status != statusOK || len(r.Results) == 0 || r.Results[0].Geometry.Viewport == nil {
+		return nil
+	}
+	v := r.Results[0].Geometry.Viewport
+	return &geo.BoundingBox{South: v.Southwest.Lat, West: v.Southwest.Lng, North: v.Northeast.Lat, East: v.Northeast.Lng}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Status == statusNoResults {
 		return nil, nil
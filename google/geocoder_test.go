@@ -24,6 +24,36 @@ func TestGeocode(t *testing.T) {
 	assert.Equal(t, geo.Location{Lat: -37.8137683, Lng: 144.9718448}, *location)
 }
 
+func TestNew(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		resp.Write([]byte(response1))
+	}))
+	defer ts.Close()
+
+	geocoder, err := geo.New("google", geo.Options{Key: "key", BaseURL: ts.URL + "/?", Language: "en-AU", Region: "AU"})
+	assert.NoError(t, err)
+	location, err := geocoder.Geocode("60 Collins St, Melbourne VIC 3000")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.8137683, Lng: 144.9718448}, *location)
+	assert.True(t, strings.HasSuffix(query, "&language=en-AU&region=au"))
+
+	_, err = geo.New("google", geo.Options{})
+	assert.Equal(t, geo.ErrMissingCredentials, err)
+}
+
+func TestGeocodeResult(t *testing.T) {
+	ts := testServer(response1)
+	defer ts.Close()
+
+	geocoder := google.Geocoder(token, ts.URL+"/").(geo.ResultGeocoder)
+	result, err := geocoder.GeocodeResult("60 Collins St, Melbourne VIC 3000")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.8137683, Lng: 144.9718448}, result.Location)
+	assert.Equal(t, &geo.BoundingBox{South: -37.8151172802915, West: 144.9704958197085, North: -37.8124193197085, East: 144.9731937802915}, result.BoundingBox)
+}
+
 func TestReverseGeocode(t *testing.T) {
 	ts := testServer(response2)
 	defer ts.Close()
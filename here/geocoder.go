@@ -3,12 +3,17 @@ package here
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/codingsince1985/geo-golang"
 )
 
 type (
-	baseURL         struct{ forGeocode, forReverseGeocode string }
+	baseURL struct {
+		forGeocode, forReverseGeocode string
+		radius                        int
+	}
+
 	geocodeResponse struct {
 		Response struct {
 			View []struct {
@@ -17,6 +22,11 @@ type (
 						DisplayPosition struct {
 							Latitude, Longitude float64
 						}
+						MapView *struct {
+							TopLeft, BottomRight struct {
+								Latitude, Longitude float64
+							}
+						}
 						Address struct {
 							Label          string
 							Country        string
@@ -46,18 +56,36 @@ const (
 	KeyCountyName  = "CountyName"
 )
 
-var r = 100
+// defaultRadius is the radius in meters searched by reverse geocoding unless given
+const defaultRadius = 100
+
+func init() {
+	geo.Register("here6", func(o geo.Options) (geo.Geocoder, error) {
+		if o.ID == "" || o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		radius := defaultRadius
+		if r, ok := o.Extra["radius"]; ok {
+			var err error
+			if radius, err = strconv.Atoi(r); err != nil {
+				return nil, fmt.Errorf("invalid radius %q", r)
+			}
+		}
+		return o.Apply(Geocoder(o.ID, o.Key, radius, o.BaseURLs()...).(geo.HTTPGeocoder), "language", ""), nil
+	})
+}
 
 // Geocoder constructs HERE geocoder
 func Geocoder(id, code string, radius int, baseURLs ...string) geo.Geocoder {
-	if radius > 0 {
-		r = radius
+	if radius <= 0 {
+		radius = defaultRadius
 	}
 	p := "gen=9&app_id=" + id + "&app_code=" + code
 	return geo.HTTPGeocoder{
 		EndpointBuilder: baseURL{
 			getGeocodeURL(p, baseURLs...),
-			getReverseGeocodeURL(p, baseURLs...)},
+			getReverseGeocodeURL(p, baseURLs...),
+			radius},
 		ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
 	}
 }
@@ -76,10 +104,28 @@ func getReverseGeocodeURL(p string, baseURLs ...string) string {
 	return "http://reverse.geocoder.api.here.com/6.2/reversegeocode.json?mode=retrieveAddresses&" + p
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "here6",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: "© HERE",
+		Licence:     "HERE Terms and Conditions",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string { return b.forGeocode + "&searchtext=" + address }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
-	return b.forReverseGeocode + fmt.Sprintf("&prox=%f,%f,%d", l.Lat, l.Lng, r)
+	return b.forReverseGeocode + fmt.Sprintf("&prox=%f,%f,%d", l.Lat, l.Lng, b.radius)
 }
 
 func (r *geocodeResponse) Location() (*geo.Location, error) {
@@ -93,6 +139,22 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.Response.View) == 0 || len(r.Response.View[0].Result) == 0 {
+		return nil
+	}
+	v := r.Response.View[0].Result[0].Location.MapView
+	if v == nil {
+		return nil
+	}
+	return &geo.BoundingBox{
+		South: v.BottomRight.Latitude,
+		West:  v.TopLeft.Longitude,
+		North: v.TopLeft.Latitude,
+		East:  v.BottomRight.Longitude,
+	}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if len(r.Response.View) == 0 || len(r.Response.View[0].Result) == 0 {
 		return nil, nil
@@ -44,6 +44,23 @@ func TestReverseGeocodeWithNoResult(t *testing.T) {
 	assert.Nil(t, addr)
 }
 
+func TestGeocodersKeepTheirRadius(t *testing.T) {
+	prox := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		prox[req.URL.Path] = req.URL.Query().Get("prox")
+		resp.Write([]byte(response2))
+	}))
+	defer ts.Close()
+
+	first := here.Geocoder(appID, appCode, 100, ts.URL+"/1?")
+	second := here.Geocoder(appID, appCode, 200, ts.URL+"/2?")
+	for _, g := range []geo.Geocoder{first, second} {
+		_, err := g.ReverseGeocode(-37.81375, 144.97176)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, map[string]string{"/1": "-37.813750,144.971760,100", "/2": "-37.813750,144.971760,200"}, prox)
+}
+
 func testServer(response string) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 		resp.Write([]byte(response))
@@ -0,0 +1,95 @@
+package here
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// DefaultTokenURL is the endpoint issuing OAuth 2.0 tokens for HERE platform credentials
+const DefaultTokenURL = "https://account.api.here.com/oauth2/token"
+
+// OAuthTokenSource returns a TokenSource of tokens requested with the access key of HERE platform credentials
+// from DefaultTokenURL, or tokenURLs[0], and refreshed before they expire
+func OAuthTokenSource(accessKeyID, accessKeySecret string, tokenURLs ...string) geo.TokenSource {
+	tokenURL := DefaultTokenURL
+	if len(tokenURLs) > 0 {
+		tokenURL = tokenURLs[0]
+	}
+	return geo.RefreshingTokenSource(func(client *http.Client) (string, time.Time, error) {
+		body := url.Values{"grant_type": {"client_credentials"}}
+		header := http.Header{}
+		header.Set("Authorization", signOAuth1(http.MethodPost, tokenURL, body, accessKeyID, accessKeySecret,
+			nonce(), strconv.FormatInt(time.Now().Unix(), 10)))
+
+		var r struct {
+			AccessToken      string `json:"access_token"`
+			ExpiresIn        int    `json:"expires_in"`
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if err := geo.PostTokenForm(client, tokenURL, body, header, &r); err != nil {
+			return "", time.Time{}, err
+		}
+		if r.Error != "" {
+			return "", time.Time{}, fmt.Errorf("token error: %s %s", r.Error, r.ErrorDescription)
+		}
+		return r.AccessToken, geo.TokenExpiry(r.ExpiresIn), nil
+	})
+}
+
+// signOAuth1 returns the OAuth 1.0 HMAC-SHA256 Authorization header HERE requires to issue tokens
+func signOAuth1(method, endpoint string, body url.Values, key, secret, nonce, timestamp string) string {
+	oauth := map[string]string{
+		"oauth_consumer_key":     key,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": "HMAC-SHA256",
+		"oauth_timestamp":        timestamp,
+		"oauth_version":          "1.0",
+	}
+
+	var params []string
+	for k, v := range oauth {
+		params = append(params, percentEncode(k)+"="+percentEncode(v))
+	}
+	for k, vs := range body {
+		for _, v := range vs {
+			params = append(params, percentEncode(k)+"="+percentEncode(v))
+		}
+	}
+	sort.Strings(params)
+
+	base := method + "&" + percentEncode(endpoint) + "&" + percentEncode(strings.Join(params, "&"))
+	mac := hmac.New(sha256.New, []byte(percentEncode(secret)+"&"))
+	mac.Write([]byte(base))
+	oauth["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	var header []string
+	for k, v := range oauth {
+		header = append(header, fmt.Sprintf(`%s="%s"`, k, percentEncode(v)))
+	}
+	sort.Strings(header)
+	return "OAuth " + strings.Join(header, ",")
+}
+
+// percentEncode escapes s as RFC 3986 requires for OAuth 1.0
+func percentEncode(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func nonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,56 @@
+package here
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSignOAuth1(t *testing.T) {
+	header := signOAuth1(http.MethodPost, DefaultTokenURL, url.Values{"grant_type": {"client_credentials"}},
+		"key-id", "s3cr/t+", "abc123", "1600000000")
+	// signature computed independently for the same base string and key
+	if !strings.Contains(header, `oauth_signature="uVF6fP43IKAqhMo9lB%2FKRPVDTYxUXpPdrLbhgAF8c%2F4%3D"`) {
+		t.Fatalf("Got: %s\tExpected: HMAC-SHA256 signature\n", header)
+	}
+	if !strings.HasPrefix(header, `OAuth oauth_consumer_key="key-id",oauth_nonce="abc123",oauth_signature=`) {
+		t.Fatalf("Got: %s\tExpected: OAuth header\n", header)
+	}
+}
+
+func TestOAuthTokenSourceCaches(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		req.ParseForm()
+		if req.PostForm.Get("grant_type") != "client_credentials" || !strings.HasPrefix(req.Header.Get("Authorization"), "OAuth ") {
+			t.Fatalf("Got: %v %v\tExpected: signed client credentials\n", req.PostForm, req.Header)
+		}
+		resp.Write([]byte(`{"access_token": "token", "token_type": "bearer", "expires_in": 86399}`))
+	}))
+	defer ts.Close()
+
+	tokens := OAuthTokenSource("key-id", "secret", ts.URL)
+	for i := 0; i < 2; i++ {
+		if token, err := tokens.Token(nil); err != nil || token != "token" {
+			t.Fatalf("Got: %v %v\tExpected: token\n", token, err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("Got: %d requests\tExpected: 1\n", requests)
+	}
+}
+
+func TestOAuthTokenError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.WriteHeader(http.StatusUnauthorized)
+		resp.Write([]byte(`{"error": "invalid_client", "error_description": "Invalid client credentials."}`))
+	}))
+	defer ts.Close()
+
+	if _, err := OAuthTokenSource("key-id", "wrong", ts.URL).Token(nil); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("Got: %v\tExpected: token error\n", err)
+	}
+}
@@ -0,0 +1,302 @@
+package here
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+type (
+	v7Endpoints struct {
+		geocode, revgeocode, autosuggest, discover string
+		apiKey                                     string
+		tokens                                     geo.TokenSource
+	}
+
+	v7Response struct {
+		Items            []v7Item
+		Status           int
+		Title            string
+		Cause            string
+		Error            string
+		ErrorDescription string `json:"error_description"`
+		httpStatus       int
+	}
+
+	v7Item struct {
+		Title      string
+		ID         string
+		ResultType string
+		Address    struct {
+			Label       string
+			CountryCode string
+			CountryName string
+			StateCode   string
+			State       string
+			County      string
+			City        string
+			District    string
+			Street      string
+			PostalCode  string
+			HouseNumber string
+		}
+		Position *struct {
+			Lat, Lng float64
+		}
+		MapView *struct {
+			West, South, East, North float64
+		}
+		Distance int
+		Scoring  Scoring
+	}
+)
+
+// Item is a result of HERE Geocoding & Search v7
+type Item struct {
+	Title      string
+	ID         string
+	ResultType string // such as houseNumber, street, locality or place
+	Address    geo.Address
+	Position   *geo.Location    // nil for autosuggest queries
+	MapView    *geo.BoundingBox // nil if the service gives none
+	Distance   int              // in meters from the location searched at, if any
+	Scoring    Scoring
+}
+
+// Scoring tells how well an Item matches the query, from 0 to 1
+type Scoring struct {
+	QueryScore float64
+	FieldScore map[string]interface{}
+}
+
+// V7 is a geocoder for HERE Geocoding & Search v7, which also offers autosuggest and discover queries
+type V7 struct {
+	geo.HTTPGeocoder
+	endpoints v7Endpoints
+}
+
+func init() {
+	geo.Register("here", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		g := GeocoderV7(o.Key, o.BaseURLs()...)
+		g.HTTPGeocoder = o.Apply(g.HTTPGeocoder, "lang", "")
+		return g, nil
+	})
+}
+
+// GeocoderV7 constructs HERE Geocoding & Search v7 geocoder authenticating with apiKey.
+// baseURLs[0], if given, replaces the hosts of all endpoints
+func GeocoderV7(apiKey string, baseURLs ...string) V7 {
+	e := getV7Endpoints(baseURLs...)
+	e.apiKey = apiKey
+	return newV7(e)
+}
+
+// GeocoderV7WithToken constructs HERE Geocoding & Search v7 geocoder authenticating with OAuth 2.0 bearer tokens,
+// such as those of OAuthTokenSource
+func GeocoderV7WithToken(tokens geo.TokenSource, baseURLs ...string) V7 {
+	e := getV7Endpoints(baseURLs...)
+	e.tokens = tokens
+	return newV7(e)
+}
+
+func newV7(e v7Endpoints) V7 {
+	return V7{
+		HTTPGeocoder: geo.HTTPGeocoder{
+			EndpointBuilder:       e,
+			ResponseParserFactory: func() geo.ResponseParser { return &v7Response{} },
+		},
+		endpoints: e,
+	}
+}
+
+func getV7Endpoints(baseURLs ...string) v7Endpoints {
+	if len(baseURLs) > 0 {
+		b := baseURLs[0]
+		return v7Endpoints{geocode: b + "geocode", revgeocode: b + "revgeocode", autosuggest: b + "autosuggest", discover: b + "discover"}
+	}
+	return v7Endpoints{
+		geocode:     "https://geocode.search.hereapi.com/v1/geocode",
+		revgeocode:  "https://revgeocode.search.hereapi.com/v1/revgeocode",
+		autosuggest: "https://autosuggest.search.hereapi.com/v1/autosuggest",
+		discover:    "https://discover.search.hereapi.com/v1/discover",
+	}
+}
+
+// v7Capabilities are those of Geocoding & Search v7
+var v7Capabilities = geo.Capabilities{
+	Provider:           "here",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	MultipleResults:    true,
+	Languages:          true,
+	BoundingBoxBias:    true,
+	RateLimit:          5,
+	Terms: geo.Terms{
+		Attribution: "© HERE",
+		Licence:     "HERE Terms and Conditions",
+	},
+}
+
+// Capabilities returns the capabilities of Geocoding & Search v7
+func (e v7Endpoints) Capabilities() geo.Capabilities { return v7Capabilities }
+
+func (e v7Endpoints) url(endpoint, params string) string {
+	if e.apiKey != "" {
+		params += "&apiKey=" + url.QueryEscape(e.apiKey)
+	}
+	return endpoint + "?" + params
+}
+
+func (e v7Endpoints) GeocodeURL(address string) string { return e.url(e.geocode, "q="+address) }
+
+func (e v7Endpoints) ReverseGeocodeURL(l geo.Location) string {
+	return e.url(e.revgeocode, fmt.Sprintf("at=%f,%f", l.Lat, l.Lng))
+}
+
+// Authorize adds the bearer token, requested with the client of the geocoder, to req, if authenticating with tokens
+func (e v7Endpoints) Authorize(req *http.Request) error {
+	if e.tokens == nil {
+		return nil
+	}
+	token, err := e.tokens.Token(geo.RequestClient(req))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Lookup returns the items matching address, best first
+func (g V7) Lookup(address string) ([]Item, error) {
+	return g.items(g.endpoints.GeocodeURL(url.QueryEscape(address)))
+}
+
+// ReverseLookup returns the items nearest to location, nearest first
+func (g V7) ReverseLookup(lat, lng float64) ([]Item, error) {
+	return g.items(g.endpoints.ReverseGeocodeURL(geo.Location{Lat: lat, Lng: lng}))
+}
+
+// Autosuggest returns the items completing the partial query q, typed near at
+func (g V7) Autosuggest(q string, at geo.Location) ([]Item, error) {
+	return g.items(g.endpoints.url(g.endpoints.autosuggest, fmt.Sprintf("q=%s&at=%f,%f", url.QueryEscape(q), at.Lat, at.Lng)))
+}
+
+// Discover returns the places, such as businesses or landmarks, matching the free-form query q near at
+func (g V7) Discover(q string, at geo.Location) ([]Item, error) {
+	return g.items(g.endpoints.url(g.endpoints.discover, fmt.Sprintf("q=%s&at=%f,%f", url.QueryEscape(q), at.Lat, at.Lng)))
+}
+
+func (g V7) items(endpoint string) ([]Item, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	var r v7Response
+	if err := g.Do(req, &r); err != nil {
+		return nil, err
+	}
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, len(r.Items))
+	for i, it := range r.Items {
+		items[i] = Item{
+			Title:      it.Title,
+			ID:         it.ID,
+			ResultType: it.ResultType,
+			Address:    *it.address(),
+			Position:   it.location(),
+			MapView:    it.boundingBox(),
+			Distance:   it.Distance,
+			Scoring:    it.Scoring,
+		}
+	}
+	return items, nil
+}
+
+// SetStatus keeps the HTTP status of the response, whose errors do not always come with a status in their body
+func (r *v7Response) SetStatus(status int) { r.httpStatus = status }
+
+func (r *v7Response) err() error {
+	status := r.Status
+	if status == 0 && (r.httpStatus < http.StatusOK || r.httpStatus >= http.StatusMultipleChoices) {
+		status = r.httpStatus
+	}
+	if status < http.StatusBadRequest && r.Error == "" && r.ErrorDescription == "" {
+		return nil
+	}
+	title := r.Title
+	if title == "" {
+		title = r.Error
+	}
+	return fmt.Errorf("geocoding error %d: %s", status, strings.TrimSpace(title+" "+r.ErrorDescription+" "+r.Cause))
+}
+
+func (r *v7Response) Location() (*geo.Location, error) {
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	if len(r.Items) == 0 {
+		return nil, nil
+	}
+	return r.Items[0].location(), nil
+}
+
+func (r *v7Response) BoundingBox() *geo.BoundingBox {
+	if len(r.Items) == 0 {
+		return nil
+	}
+	return r.Items[0].boundingBox()
+}
+
+func (r *v7Response) Address() (*geo.Address, error) {
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	if len(r.Items) == 0 {
+		return nil, nil
+	}
+	return r.Items[0].address(), nil
+}
+
+func (it v7Item) location() *geo.Location {
+	if it.Position == nil {
+		return nil
+	}
+	return &geo.Location{Lat: it.Position.Lat, Lng: it.Position.Lng}
+}
+
+func (it v7Item) boundingBox() *geo.BoundingBox {
+	if it.MapView == nil {
+		return nil
+	}
+	v := it.MapView
+	return &geo.BoundingBox{South: v.South, West: v.West, North: v.North, East: v.East}
+}
+
+func (it v7Item) address() *geo.Address {
+	a := it.Address
+	addr := &geo.Address{
+		FormattedAddress: a.Label,
+		Street:           a.Street,
+		HouseNumber:      a.HouseNumber,
+		Suburb:           a.District,
+		Postcode:         a.PostalCode,
+		State:            a.State,
+		County:           a.County,
+		Country:          a.CountryName,
+		CountryCode:      a.CountryCode,
+		City:             a.City,
+	}
+	addr.NormalizeCountry()
+	return addr
+}
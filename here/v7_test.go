@@ -0,0 +1,221 @@
+package here_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/here"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticToken string
+
+func (t staticToken) Token(*http.Client) (string, error) { return string(t), nil }
+
+func TestGeocodeV7(t *testing.T) {
+	var req *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
+		req = r
+		resp.Write([]byte(v7GeocodeResp))
+	}))
+	defer ts.Close()
+
+	location, err := here.GeocoderV7("key", ts.URL+"/").Geocode("60 Collins St, Melbourne VIC 3000")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.81419, Lng: 144.97186}, *location)
+	assert.Equal(t, "/geocode", req.URL.Path)
+	assert.Equal(t, "key", req.URL.Query().Get("apiKey"))
+	assert.Equal(t, "60 Collins St, Melbourne VIC 3000", req.URL.Query().Get("q"))
+}
+
+func TestReverseGeocodeV7(t *testing.T) {
+	ts := testServer(v7ReverseResp)
+	defer ts.Close()
+
+	address, err := here.GeocoderV7("key", ts.URL+"/").ReverseGeocode(-37.81419, 144.97186)
+	assert.NoError(t, err)
+	source := address.Source
+	assert.Equal(t, "here", source.Provider)
+	assert.Equal(t, geo.Terms{Attribution: "© HERE", Licence: "HERE Terms and Conditions"}, source.Terms)
+	// the key is not revealed
+	assert.Equal(t, ts.URL+"/revgeocode?at=-37.814190,144.971860&apiKey=REDACTED", source.URL)
+	assert.Equal(t, http.StatusOK, source.Status)
+	assert.True(t, source.Latency > 0)
+	address.Source = nil
+	assert.Equal(t, geo.Address{
+		FormattedAddress: "60 Collins St, Melbourne VIC 3000, Australia",
+		Street:           "Collins St",
+		HouseNumber:      "60",
+		Suburb:           "Melbourne",
+		Postcode:         "3000",
+		State:            "Victoria",
+		Country:          "Australia",
+		CountryCode:      "AU",
+		City:             "Melbourne",
+	}, *address)
+}
+
+func TestLookupV7WithToken(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		resp.Write([]byte(v7GeocodeResp))
+	}))
+	defer ts.Close()
+
+	items, err := here.GeocoderV7WithToken(staticToken("token"), ts.URL+"/").Lookup("60 Collins St")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", auth)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "houseNumber", items[0].ResultType)
+	assert.Equal(t, 0.96, items[0].Scoring.QueryScore)
+	assert.Equal(t, 1.0, items[0].Scoring.FieldScore["houseNumber"])
+}
+
+type recordingTransport struct{ paths []string }
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.paths = append(r.paths, req.URL.Path)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestLookupV7WithTokenClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			resp.Write([]byte(`{"access_token": "token", "token_type": "bearer", "expires_in": 86399}`))
+			return
+		}
+		resp.Write([]byte(v7GeocodeResp))
+	}))
+	defer ts.Close()
+
+	transport := &recordingTransport{}
+	g := here.GeocoderV7WithToken(here.OAuthTokenSource("key-id", "secret", ts.URL+"/oauth2/token"), ts.URL+"/")
+	g.Client = &http.Client{Transport: transport}
+	_, err := g.Lookup("60 Collins St")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/oauth2/token", "/geocode"}, transport.paths)
+}
+
+func TestAutosuggestV7(t *testing.T) {
+	var req *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
+		req = r
+		resp.Write([]byte(v7AutosuggestResp))
+	}))
+	defer ts.Close()
+
+	items, err := here.GeocoderV7("key", ts.URL+"/").Autosuggest("60 coll", geo.Location{Lat: -37.8, Lng: 144.9})
+	assert.NoError(t, err)
+	assert.Equal(t, "/autosuggest", req.URL.Path)
+	assert.Equal(t, "-37.800000,144.900000", req.URL.Query().Get("at"))
+	assert.Len(t, items, 2)
+	assert.Nil(t, items[1].Position)
+	assert.Equal(t, 1234, items[0].Distance)
+}
+
+func TestGeocodeV7Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
+		resp.WriteHeader(http.StatusUnauthorized)
+		resp.Write([]byte(`{"error": "Unauthorized", "error_description": "apiKey invalid. apiKey not found."}`))
+	}))
+	defer ts.Close()
+
+	g := here.GeocoderV7("wrong", ts.URL+"/")
+	_, err := g.Geocode("60 Collins St")
+	if assert.Error(t, err) {
+		assert.Equal(t, "geocoding error 401: Unauthorized apiKey invalid. apiKey not found.", err.Error())
+	}
+	_, err = g.Lookup("60 Collins St")
+	assert.Error(t, err)
+}
+
+func TestGeocodeV7HTTPStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
+		resp.WriteHeader(http.StatusServiceUnavailable)
+		resp.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	_, err := here.GeocoderV7("key", ts.URL+"/").Geocode("60 Collins St")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "503")
+	}
+}
+
+const (
+	v7GeocodeResp = `{
+  "items": [
+    {
+      "title": "60 Collins St, Melbourne VIC 3000, Australia",
+      "id": "here:af:streetsection:abc:CgcIBCCf",
+      "resultType": "houseNumber",
+      "houseNumberType": "PA",
+      "address": {
+        "label": "60 Collins St, Melbourne VIC 3000, Australia",
+        "countryCode": "AUS",
+        "countryName": "Australia",
+        "stateCode": "VIC",
+        "state": "Victoria",
+        "city": "Melbourne",
+        "district": "Melbourne",
+        "street": "Collins St",
+        "postalCode": "3000",
+        "houseNumber": "60"
+      },
+      "position": {"lat": -37.81419, "lng": 144.97186},
+      "access": [{"lat": -37.81436, "lng": 144.97195}],
+      "mapView": {"west": 144.97072, "south": -37.81509, "east": 144.973, "north": -37.81329},
+      "scoring": {
+        "queryScore": 0.96,
+        "fieldScore": {"state": 1.0, "city": 1.0, "streets": [1.0], "houseNumber": 1.0, "postalCode": 1.0}
+      }
+    }
+  ]
+}`
+
+	v7ReverseResp = `{
+  "items": [
+    {
+      "title": "60 Collins St, Melbourne VIC 3000, Australia",
+      "id": "here:af:streetsection:abc:CgcIBCCf",
+      "resultType": "houseNumber",
+      "address": {
+        "label": "60 Collins St, Melbourne VIC 3000, Australia",
+        "countryCode": "AUS",
+        "countryName": "Australia",
+        "stateCode": "VIC",
+        "state": "Victoria",
+        "city": "Melbourne",
+        "district": "Melbourne",
+        "street": "Collins St",
+        "postalCode": "3000",
+        "houseNumber": "60"
+      },
+      "position": {"lat": -37.81419, "lng": 144.97186},
+      "distance": 0
+    }
+  ]
+}`
+
+	v7AutosuggestResp = `{
+  "items": [
+    {
+      "title": "60 Collins St, Melbourne VIC 3000, Australia",
+      "id": "here:af:streetsection:abc:CgcIBCCf",
+      "resultType": "houseNumber",
+      "address": {"label": "60 Collins St, Melbourne VIC 3000, Australia"},
+      "position": {"lat": -37.81419, "lng": 144.97186},
+      "distance": 1234
+    },
+    {
+      "title": "60 Collins St",
+      "id": "here:cm:query:60",
+      "resultType": "chainQuery",
+      "address": {"label": "60 Collins St"}
+    }
+  ]
+}`
+)
@@ -24,6 +24,12 @@ type EndpointBuilder interface {
 	ReverseGeocodeURL(Location) string
 }
 
+// Authorizer is implemented by EndpointBuilders whose service needs credentials added to each request,
+// such as a token that expires, which they may request with RequestClient
+type Authorizer interface {
+	Authorize(*http.Request) error
+}
+
 // ResponseParserFactory creates a new ResponseParser
 type ResponseParserFactory func() ResponseParser
 
@@ -33,36 +39,91 @@ type ResponseParser interface {
 	Address() (*Address, error)
 }
 
+// BoundingBoxParser is implemented by ResponseParsers of services which return the extent of a location
+type BoundingBoxParser interface {
+	BoundingBox() *BoundingBox
+}
+
+// TimezoneParser is implemented by ResponseParsers of services which return the IANA timezone of a location
+type TimezoneParser interface {
+	Timezone() string
+}
+
+// StatusParser is implemented by ResponseParsers of services which tell errors by the HTTP status of their responses,
+// set before the response is parsed
+type StatusParser interface {
+	SetStatus(status int)
+}
+
 // HTTPGeocoder has EndpointBuilder and ResponseParser
 type HTTPGeocoder struct {
 	EndpointBuilder
 	ResponseParserFactory
+	// Client sends the requests, http.DefaultClient if nil
+	Client *http.Client
+	// Params are added to the query of every request, such as the language of results
+	Params url.Values
+	// Raw keeps the JSON responses of the service in the sources of results, to reach the fields they do not model
+	Raw bool
+	// Timeout is how long a request can take, DefaultTimeout if zero
+	Timeout time.Duration
 }
 
 // Geocode returns location for address
 func (g HTTPGeocoder) Geocode(address string) (*Location, error) {
+	res, err := g.GeocodeResult(address)
+	if res == nil {
+		return nil, err
+	}
+	return &res.Location, err
+}
+
+// GeocodeResult returns location for address, with its bounding box and address if the service supplies them
+func (g HTTPGeocoder) GeocodeResult(address string) (*Result, error) {
 	responseParser := g.ResponseParserFactory()
 
-	ctx, cancel := context.WithTimeout(context.TODO(), DefaultTimeout)
+	ctx, cancel := context.WithTimeout(context.TODO(), g.timeout())
 	defer cancel()
 
 	type geoResp struct {
-		l *Location
+		r *Result
 		e error
 	}
 	ch := make(chan geoResp, 1)
 
 	go func(ch chan geoResp) {
-		if err := response(ctx, g.GeocodeURL(url.QueryEscape(address)), responseParser); err != nil {
+		source, err := g.response(ctx, g.GeocodeURL(url.QueryEscape(address)), responseParser)
+		if err != nil {
 			ch <- geoResp{
-				l: nil,
+				r: nil,
 				e: err,
 			}
+			return
 		}
 
 		loc, err := responseParser.Location()
+		if loc == nil {
+			ch <- geoResp{
+				r: nil,
+				e: err,
+			}
+			return
+		}
+		res := &Result{Location: *loc}
+		if p, ok := responseParser.(BoundingBoxParser); ok {
+			res.BoundingBox = p.BoundingBox()
+		}
+		if p, ok := responseParser.(TimezoneParser); ok {
+			res.Timezone = p.Timezone()
+		}
+		// most services describe the place found as they do in reverse geocoding
+		if addr, err := responseParser.Address(); err == nil && addr != nil && addr.FormattedAddress != "" {
+			addr.NormalizeCountry()
+			res.Address = addr
+		}
+		res.Source = source
 		ch <- geoResp{
-			l: loc,
+			r: res,
 			e: err,
 		}
 	}(ch)
@@ -71,7 +132,7 @@ func (g HTTPGeocoder) Geocode(address string) (*Location, error) {
 	case <-ctx.Done():
 		return nil, ErrTimeout
 	case res := <-ch:
-		return res.l, res.e
+		return res.r, res.e
 	}
 }
 
@@ -79,7 +140,7 @@ func (g HTTPGeocoder) Geocode(address string) (*Location, error) {
 func (g HTTPGeocoder) ReverseGeocode(lat, lng float64) (*Address, error) {
 	responseParser := g.ResponseParserFactory()
 
-	ctx, cancel := context.WithTimeout(context.TODO(), DefaultTimeout)
+	ctx, cancel := context.WithTimeout(context.TODO(), g.timeout())
 	defer cancel()
 
 	type revResp struct {
@@ -89,14 +150,25 @@ func (g HTTPGeocoder) ReverseGeocode(lat, lng float64) (*Address, error) {
 	ch := make(chan revResp, 1)
 
 	go func(ch chan revResp) {
-		if err := response(ctx, g.ReverseGeocodeURL(Location{lat, lng}), responseParser); err != nil {
+		source, err := g.response(ctx, g.ReverseGeocodeURL(Location{lat, lng}), responseParser)
+		if err != nil {
 			ch <- revResp{
 				a: nil,
 				e: err,
 			}
+			return
 		}
 
 		addr, err := responseParser.Address()
+		if addr != nil {
+			addr.NormalizeCountry()
+			if p, ok := responseParser.(TimezoneParser); ok && addr.Timezone == "" {
+				addr.Timezone = p.Timezone()
+			}
+			if addr.Source == nil {
+				addr.Source = source
+			}
+		}
 		ch <- revResp{
 			a: addr,
 			e: err,
@@ -111,35 +183,129 @@ func (g HTTPGeocoder) ReverseGeocode(lat, lng float64) (*Address, error) {
 	}
 }
 
-// Response gets response from url
-func response(ctx context.Context, url string, obj ResponseParser) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// source returns the source of the response of req, with the provider and terms reported by the service if any,
+// and those of the response parsed by p if it tells them
+func (g HTTPGeocoder) source(req *http.Request, status int, latency time.Duration, p ResponseParser, data []byte) *Source {
+	c := g.Capabilities()
+	s := &Source{Provider: c.Provider, Terms: c.Terms, URL: redact(req.URL), Status: status, Latency: latency}
+	if g.Raw && len(data) > 0 {
+		s.Raw = data
+	}
+	if a, ok := p.(LicensingParser); ok {
+		if attribution, licence := a.Licensing(); attribution != "" || licence != "" {
+			s.Attribution, s.Licence = attribution, licence
+		}
+	}
+	return s
+}
+
+// Do sends req and decodes the JSON response into obj, for services with requests beyond a single GET
+func (g HTTPGeocoder) Do(req *http.Request, obj interface{}) error {
+	ctx, cancel := context.WithTimeout(req.Context(), g.timeout())
+	defer cancel()
+
+	if err := g.prepare(req); err != nil {
+		return err
+	}
+	resp, err := g.client().Do(req.WithContext(ctx))
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ErrTimeout
+		}
 		return err
 	}
-	req = req.WithContext(ctx)
 
-	resp, err := http.DefaultClient.Do(req)
+	defer resp.Body.Close()
+	if s, ok := obj.(StatusParser); ok {
+		s.SetStatus(resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		Logger.Printf("payload: %s\n", data)
+		return err
+	}
+
+	return nil
+}
+
+func (g HTTPGeocoder) timeout() time.Duration {
+	if g.Timeout > 0 {
+		return g.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (g HTTPGeocoder) client() *http.Client {
+	if g.Client != nil {
+		return g.Client
+	}
+	return http.DefaultClient
+}
+
+// prepare adds the params and credentials of the service to req
+func (g HTTPGeocoder) prepare(req *http.Request) error {
+	if len(g.Params) > 0 {
+		if req.URL.RawQuery != "" {
+			req.URL.RawQuery += "&"
+		}
+		req.URL.RawQuery += g.Params.Encode()
+	}
+	if a, ok := g.EndpointBuilder.(Authorizer); ok {
+		*req = *req.WithContext(context.WithValue(req.Context(), clientKey{}, g.Client))
+		return a.Authorize(req)
+	}
+	return nil
+}
+
+type clientKey struct{}
+
+// RequestClient returns the Client of the HTTPGeocoder preparing req, for Authorizers requesting credentials,
+// or nil if it has none
+func RequestClient(req *http.Request) *http.Client {
+	c, _ := req.Context().Value(clientKey{}).(*http.Client)
+	return c
+}
+
+// Response gets response from url, and returns its source
+func (g HTTPGeocoder) response(ctx context.Context, url string, obj ResponseParser) (*Source, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := g.prepare(req); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := g.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
 
 	defer resp.Body.Close()
+	if s, ok := obj.(StatusParser); ok {
+		s.SetStatus(resp.StatusCode)
+	}
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	latency := time.Since(start)
 
 	body := strings.Trim(string(data), " []")
 	if body == "" {
-		return nil
+		return g.source(req, resp.StatusCode, latency, obj, nil), nil
 	}
 	if err := json.Unmarshal([]byte(body), obj); err != nil {
 		Logger.Printf("payload: %s\n", body)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return g.source(req, resp.StatusCode, latency, obj, data), nil
 }
 
 // ParseFloat is a helper to parse a string to a float
@@ -0,0 +1,510 @@
+package geo
+
+import "math"
+
+// Karney's solution of the inverse geodesic problem, from C. F. F. Karney, Algorithms for geodesics,
+// J. Geodesy 87, 43–55 (2013), after GeographicLib with series to the sixth order. Unlike Vincenty's formulae
+// it converges for all locations, nearly antipodal ones included
+
+const (
+	karneyOrder  = 6
+	karneyMaxit1 = 20
+	karneyMaxit2 = karneyMaxit1 + 53 + 10
+)
+
+var (
+	karneyTiny    = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52))
+	karneyTol0    = math.Nextafter(1, 2) - 1
+	karneyTol1    = 200 * karneyTol0
+	karneyTol2    = math.Sqrt(karneyTol0)
+	karneyTolb    = karneyTol0 * karneyTol2
+	karneyXthresh = 1000 * karneyTol2
+)
+
+// karneyEllipsoid holds the WGS84 constants and the coefficients of the series in the third flattening
+type karneyEllipsoid struct {
+	a, f, f1, e2, ep2, n, b, etol2 float64
+	a3x                            [karneyOrder]float64
+	c3x                            [karneyOrder * (karneyOrder - 1) / 2]float64
+}
+
+var wgs84 = newKarneyEllipsoid(EquatorialRadius, Flattening)
+
+func newKarneyEllipsoid(a, f float64) *karneyEllipsoid {
+	e := &karneyEllipsoid{a: a, f: f, f1: 1 - f, e2: f * (2 - f), n: f / (2 - f), b: a * (1 - f)}
+	e.ep2 = e.e2 / (e.f1 * e.f1)
+	e.etol2 = 0.1 * karneyTol2 / math.Sqrt(math.Max(0.001, math.Abs(f))*math.Min(1, 1-f/2)/2)
+
+	a3 := []float64{-3, 128, -2, -3, 64, -1, -3, -1, 16, 3, -1, -2, 8, 1, -1, 2, 1, 1}
+	o, k := 0, 0
+	for j := karneyOrder - 1; j >= 0; j-- {
+		m := minInt(karneyOrder-j-1, j)
+		e.a3x[k] = polyval(m, a3[o:], e.n) / a3[o+m+1]
+		k++
+		o += m + 2
+	}
+
+	c3 := []float64{
+		3, 128, 2, 5, 128, -1, 3, 3, 64, -1, 0, 1, 8, -1, 1, 4,
+		5, 256, 1, 3, 128, -3, -2, 3, 64, 1, -3, 2, 32,
+		7, 512, -10, 9, 384, 5, -9, 5, 192,
+		7, 512, -14, 7, 512,
+		21, 2560,
+	}
+	o, k = 0, 0
+	for l := 1; l < karneyOrder; l++ {
+		for j := karneyOrder - 1; j >= l; j-- {
+			m := minInt(karneyOrder-j-1, j)
+			e.c3x[k] = polyval(m, c3[o:], e.n) / c3[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+	return e
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// polyval evaluates the polynomial of degree n with coefficients p from the highest degree at x
+func polyval(n int, p []float64, x float64) float64 {
+	if n < 0 {
+		return 0
+	}
+	y := p[0]
+	for i := 1; i <= n; i++ {
+		y = y*x + p[i]
+	}
+	return y
+}
+
+func a1m1(eps float64) float64 {
+	coeff := []float64{1, 4, 64, 0, 256}
+	t := polyval(3, coeff, eps*eps) / coeff[4]
+	return (t + eps) / (1 - eps)
+}
+
+func a2m1(eps float64) float64 {
+	coeff := []float64{-11, -28, -192, 0, 256}
+	t := polyval(3, coeff, eps*eps) / coeff[4]
+	return (t - eps) / (1 + eps)
+}
+
+// series sets c[1:] to the coefficients of the series in eps of coeff, as those of C1 and C2
+func series(coeff []float64, eps float64, c []float64) {
+	eps2, d, o := eps*eps, eps, 0
+	for l := 1; l <= karneyOrder; l++ {
+		m := (karneyOrder - l) / 2
+		c[l] = d * polyval(m, coeff[o:], eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+var (
+	c1Coeff = []float64{-1, 6, -16, 32, -9, 64, -128, 2048, 9, -16, 768, 3, -5, 512, -7, 1280, -7, 2048}
+	c2Coeff = []float64{1, 2, 16, 32, 35, 64, 384, 2048, 15, 80, 768, 7, 35, 512, 63, 1280, 77, 2048}
+)
+
+func (e *karneyEllipsoid) a3(eps float64) float64 { return polyval(karneyOrder-1, e.a3x[:], eps) }
+
+func (e *karneyEllipsoid) c3(eps float64, c []float64) {
+	mult, o := 1.0, 0
+	for l := 1; l < karneyOrder; l++ {
+		m := karneyOrder - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, e.c3x[o:], eps)
+		o += m + 1
+	}
+}
+
+// sinCosSeries evaluates the sum of c[l] sin 2lx, or of c[l] cos (2l+1)x if not sinp, by Clenshaw summation
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+	k, n := len(c), len(c)
+	if sinp {
+		n--
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	if sinp {
+		return 2 * sinx * cosx * y0
+	}
+	return cosx * (y0 - y1)
+}
+
+func norm2(x, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	return x / r, y / r
+}
+
+// sincosd returns the sine and cosine of x degrees, exact for multiples of 90
+func sincosd(x float64) (float64, float64) {
+	r := math.Mod(x, 360)
+	q := int(math.Floor(r/90 + 0.5))
+	r = radians(r - 90*float64(q))
+	s, c := math.Sin(r), math.Cos(r)
+	switch uint(q) & 3 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	return s + 0, c + 0
+}
+
+// angRound rounds tiny angles so that those smaller than 1/16 degree are exact in degrees
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+	return math.Copysign(y, x)
+}
+
+// angNormalize reduces x degrees to (-180, 180]
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if math.Abs(y) == 180 {
+		return math.Copysign(180, x)
+	}
+	return y
+}
+
+// lengths returns the distance s12b if distance is set, the reduced length m12b and m0, in units of b
+func (e *karneyEllipsoid) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64, distance bool,
+	c1a, c2a []float64) (s12b, m12b, m0 float64) {
+	a1 := a1m1(eps)
+	series(c1Coeff, eps, c1a)
+	a2 := a2m1(eps)
+	series(c2Coeff, eps, c2a)
+	m0x := a1 - a2
+	a1, a2 = 1+a1, 1+a2
+
+	var j12 float64
+	if distance {
+		b1 := sinCosSeries(true, ssig2, csig2, c1a) - sinCosSeries(true, ssig1, csig1, c1a)
+		s12b = a1 * (sig12 + b1)
+		b2 := sinCosSeries(true, ssig2, csig2, c2a) - sinCosSeries(true, ssig1, csig1, c2a)
+		j12 = m0x*sig12 + (a1*b1 - a2*b2)
+	} else {
+		for l := 1; l <= karneyOrder; l++ {
+			c2a[l] = a1*c1a[l] - a2*c2a[l]
+		}
+		j12 = m0x*sig12 + (sinCosSeries(true, ssig2, csig2, c2a) - sinCosSeries(true, ssig1, csig1, c2a))
+	}
+	m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+	return s12b, m12b, m0x
+}
+
+// astroid solves k^4 + 2k^3 - (x^2 + y^2 - 1)k^2 - 2y^2k - y^2 = 0 for its positive root
+func astroid(x, y float64) float64 {
+	p, q := x*x, y*y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	uv := u + v
+	if u < 0 {
+		uv = q / (v - u)
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// inverseStart returns a first guess of the azimuth at the first location, or the solution for short lines
+// with sig12 not negative
+func (e *karneyEllipsoid) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64) (
+	sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	somg12, comg12 := slam12, clam12
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + e.ep2*sbetm2)
+		omg12 := lam12 / (e.f1 * dnm)
+		somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	switch {
+	case shortline && ssig12 < e.etol2:
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*somg12*somg12/(1+comg12)
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm2(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	case math.Abs(e.n) > 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(e.n)*math.Pi*cbet1*cbet1:
+		// nothing to change
+	default:
+		// nearly antipodal locations
+		lam12x := math.Atan2(-slam12, -clam12)
+		k2 := sbet1 * sbet1 * e.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamscale := e.f * cbet1 * e.a3(eps) * math.Pi
+		betscale := lamscale * cbet1
+		x, y := lam12x/lamscale, sbet12a/betscale
+		if y > -karneyTol1 && x > -1-karneyXthresh {
+			salp1 = math.Min(1, -x)
+			calp1 = -math.Sqrt(1 - salp1*salp1)
+		} else {
+			k := astroid(x, y)
+			omg12a := lamscale * (-x * k / (1 + k))
+			somg12, comg12 = math.Sin(omg12a), -math.Cos(omg12a)
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+	if salp1 > 0 {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12 returns the difference in longitude reached with the azimuth salp1, calp1 at the first location
+// less that of the second location, its derivative if diffp, and the geodesic
+func (e *karneyEllipsoid) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64,
+	diffp bool, c1a, c2a, c3a []float64) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12 float64) {
+	if sbet1 == 0 && calp1 == 0 {
+		// break the degeneracy of equatorial lines
+		calp1 = -karneyTiny
+	}
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1, somg1 := sbet1, salp0*sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	salp2 = salp1
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	}
+	calp2 = math.Abs(calp1)
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		d := (sbet1 - sbet2) * (sbet1 + sbet2)
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		}
+		calp2 = math.Sqrt((calp1*cbet1)*(calp1*cbet1)+d) / cbet2
+	}
+	ssig2, somg2 := sbet2, salp0*sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm2(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * e.ep2
+	eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	e.c3(eps, c3a)
+	b312 := sinCosSeries(true, ssig2, csig2, c3a) - sinCosSeries(true, ssig1, csig1, c3a)
+	lam12 = eta - e.f*e.a3(eps)*salp0*(sig12+b312)
+
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * e.f1 * dn1 / sbet1
+		} else {
+			_, dlam12, _ = e.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, false, c1a, c2a)
+			dlam12 *= e.f1 / (calp2 * cbet2)
+		}
+	}
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12
+}
+
+// inverse solves the inverse geodesic problem between from and to, for all locations
+func (e *karneyEllipsoid) inverse(from, to Location) Geodesic {
+	lon12 := angNormalize(to.Lng - from.Lng)
+	lonsign := math.Copysign(1, lon12)
+	lon12 = lonsign * angRound(lon12)
+	lon12s := angRound(180 - lon12)
+	lam12 := radians(lon12)
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	lat1, lat2 := angRound(from.Lat), angRound(to.Lat)
+	// the first location is the farthest from the equator, in the southern hemisphere
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign = -lonsign
+		lat1, lat2 = lat2, lat1
+	}
+	latsign := 1.0
+	if lat1 >= 0 {
+		latsign = -1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1, cbet1 = norm2(e.f1*sbet1, cbet1)
+	cbet1 = math.Max(karneyTiny, cbet1)
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2, cbet2 = norm2(e.f1*sbet2, cbet2)
+	cbet2 = math.Max(karneyTiny, cbet2)
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+	dn1 := math.Sqrt(1 + e.ep2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + e.ep2*sbet2*sbet2)
+
+	c1a, c2a, c3a := make([]float64, karneyOrder+1), make([]float64, karneyOrder+1), make([]float64, karneyOrder)
+	var s12x, salp1, calp1, salp2, calp2 float64
+
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12b, m12b, _ := e.lengths(e.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, true, c1a, c2a)
+		if sig12 < 1 || m12b >= 0 {
+			if sig12 < 3*karneyTiny || sig12 < karneyTol0 && (s12b < 0 || m12b < 0) {
+				s12b = 0
+			}
+			s12x = s12b * e.b
+		} else {
+			// the shortest path is not along the meridian
+			meridian = false
+		}
+	}
+
+	switch {
+	case meridian:
+	case sbet1 == 0 && (e.f <= 0 || lon12s >= e.f*180):
+		// along the equator
+		calp1, calp2, salp1, salp2 = 0, 0, 1, 1
+		s12x = e.a * lam12
+	default:
+		sig12, sa1, ca1, sa2, ca2, dnm := e.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12)
+		salp1, calp1, salp2, calp2 = sa1, ca1, sa2, ca2
+		if sig12 >= 0 {
+			// short line
+			s12x = sig12 * e.b * dnm
+			break
+		}
+		// Newton's method on the azimuth at the first location, falling back to bisection
+		var ssig1, csig1, ssig2, csig2, eps float64
+		tripn, tripb := false, false
+		salp1a, calp1a, salp1b, calp1b := karneyTiny, 1.0, karneyTiny, -1.0
+		for numit := 0; numit < karneyMaxit2; {
+			var v, dv float64
+			v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dv = e.lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2,
+				salp1, calp1, slam12, clam12, numit < karneyMaxit1, c1a, c2a, c3a)
+			tol := karneyTol0
+			if tripn {
+				tol *= 8
+			}
+			if tripb || !(math.Abs(v) >= tol) {
+				break
+			}
+			if v > 0 && (numit > karneyMaxit1 || calp1/salp1 > calp1b/salp1b) {
+				salp1b, calp1b = salp1, calp1
+			} else if v < 0 && (numit > karneyMaxit1 || calp1/salp1 < calp1a/salp1a) {
+				salp1a, calp1a = salp1, calp1
+			}
+			numit++
+			if numit < karneyMaxit1 && dv > 0 {
+				dalp1 := -v / dv
+				if math.Abs(dalp1) < math.Pi {
+					sdalp1, cdalp1 := math.Sin(dalp1), math.Cos(dalp1)
+					if nsalp1 := salp1*cdalp1 + calp1*sdalp1; nsalp1 > 0 {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1, calp1 = norm2(nsalp1, calp1)
+						tripn = math.Abs(v) <= 16*karneyTol0
+						continue
+					}
+				}
+			}
+			salp1, calp1 = norm2((salp1a+salp1b)/2, (calp1a+calp1b)/2)
+			tripn = false
+			tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < karneyTolb || math.Abs(salp1-salp1b)+(calp1-calp1b) < karneyTolb
+		}
+		s12b, _, _ := e.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, true, c1a, c2a)
+		s12x = s12b * e.b
+	}
+
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+	return Geodesic{
+		Distance:       s12x,
+		InitialBearing: bearing(math.Atan2(salp1, calp1)),
+		FinalBearing:   bearing(math.Atan2(salp2, calp2)),
+	}
+}
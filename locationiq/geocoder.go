@@ -3,18 +3,23 @@ package locationiq
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/codingsince1985/geo-golang"
 	"github.com/codingsince1985/geo-golang/osm"
 )
 
-type baseURL string
+type baseURL struct {
+	url, key string
+	zoom     int
+}
 
 type geocodeResponse struct {
 	DisplayName     string `json:"display_name"`
 	Lat, Lon, Error string
-	Addr            osm.Address `json:"address"`
+	Addr            osm.Address     `json:"address"`
+	BBox            osm.BoundingBox `json:"boundingbox"`
 }
 
 const (
@@ -24,15 +29,24 @@ const (
 	defaultZoom = 18
 )
 
-var (
-	key  string
-	zoom int
-)
+func init() {
+	geo.Register("locationiq", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		zoom := defaultZoom
+		if z, ok := o.Extra["zoom"]; ok {
+			var err error
+			if zoom, err = strconv.Atoi(z); err != nil {
+				return nil, fmt.Errorf("invalid zoom %q", z)
+			}
+		}
+		return o.Apply(Geocoder(o.Key, zoom, o.BaseURLs()...).(geo.HTTPGeocoder), "accept-language", "countrycodes"), nil
+	})
+}
 
 // Geocoder constructs LocationIQ geocoder
 func Geocoder(k string, z int, baseURLs ...string) geo.Geocoder {
-	key = k
-
 	var url string
 	if len(baseURLs) > 0 {
 		url = baseURLs[0]
@@ -40,24 +54,51 @@ func Geocoder(k string, z int, baseURLs ...string) geo.Geocoder {
 		url = defaultURL
 	}
 
-	if z > minZoom && z <= maxZoom {
-		zoom = z
-	} else {
-		zoom = defaultZoom
+	if z <= minZoom || z > maxZoom {
+		z = defaultZoom
 	}
 
 	return geo.HTTPGeocoder{
-		EndpointBuilder:       baseURL(url),
+		EndpointBuilder:       baseURL{url, k, z},
 		ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
 	}
 }
 
+// Capabilities returns the capabilities of the service, reverse geocoding as finely as the zoom of b
+func (b baseURL) Capabilities() geo.Capabilities {
+	c := geo.Capabilities{
+		Provider:           "locationiq",
+		Geocode:            true,
+		Reverse:            true,
+		ReverseGranularity: geo.GranularityAddress,
+		Structured:         true,
+		Languages:          true,
+		Region:             true,
+		BoundingBoxBias:    true,
+		RateLimit:          2,
+		Terms: geo.Terms{
+			Attribution: osm.Attribution,
+			Licence:     osm.Licence,
+		},
+	}
+	// zoom levels of Nominatim: 18 building, 16 and 17 streets, 10 to 15 cities and suburbs
+	switch {
+	case b.zoom < 10:
+		c.ReverseGranularity = geo.GranularityRegion
+	case b.zoom < 16:
+		c.ReverseGranularity = geo.GranularityLocality
+	case b.zoom < 18:
+		c.ReverseGranularity = geo.GranularityStreet
+	}
+	return c
+}
+
 func (b baseURL) GeocodeURL(address string) string {
-	return string(b) + "search.php?key=" + key + "&format=json&limit=1&q=" + address
+	return b.url + "search.php?key=" + b.key + "&format=json&limit=1&q=" + address
 }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
-	return string(b) + "reverse.php?key=" + key + fmt.Sprintf("&format=json&lat=%f&lon=%f&zoom=%d", l.Lat, l.Lng, zoom)
+	return b.url + "reverse.php?key=" + b.key + fmt.Sprintf("&format=json&lat=%f&lon=%f&zoom=%d", l.Lat, l.Lng, b.zoom)
 }
 
 func (r *geocodeResponse) Location() (*geo.Location, error) {
@@ -76,6 +117,8 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox { return r.BBox.BoundingBox() }
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Error != "" {
 		return nil, fmt.Errorf("reverse geocoding error: %s", r.Error)
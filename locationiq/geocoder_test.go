@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/codingsince1985/geo-golang"
 )
 
 func TestGeocodeYieldsResult(t *testing.T) {
@@ -30,6 +32,19 @@ func TestGeocodeYieldsResult(t *testing.T) {
 	}
 }
 
+func TestCapabilities(t *testing.T) {
+	for zoom, expected := range map[int]geo.Granularity{
+		18: geo.GranularityAddress,
+		17: geo.GranularityStreet,
+		10: geo.GranularityLocality,
+		5:  geo.GranularityRegion,
+	} {
+		if c := geo.CapabilitiesOf(Geocoder("foobar", zoom)); c.ReverseGranularity != expected {
+			t.Errorf("Expected granularity %s at zoom %d, got %s", expected, zoom, c.ReverseGranularity)
+		}
+	}
+}
+
 func TestGeocodeYieldsNoResult(t *testing.T) {
 	ts := testServer("[]")
 	defer ts.Close()
@@ -57,7 +72,7 @@ func TestReverseGeocodeYieldsResult(t *testing.T) {
 		t.Errorf("Expected nil error, got %v", err)
 	}
 	if !strings.HasPrefix(addr.FormattedAddress, "26, Seidlstraße") {
-		t.Errorf("Expected address string starting with %s, got string: %s", "26, Seidlstraße", addr)
+		t.Errorf("Expected address string starting with %s, got string: %s", "26, Seidlstraße", addr.FormattedAddress)
 	}
 }
 
@@ -72,7 +87,26 @@ func TestReverseGeocodeYieldsNoResult(t *testing.T) {
 		t.Error("Expected error, got nil")
 	}
 	if addr != nil {
-		t.Errorf("Expected nil as address, got: %s", addr)
+		t.Errorf("Expected nil as address, got: %v", addr)
+	}
+}
+
+func TestGeocodersKeepTheirZoom(t *testing.T) {
+	zooms := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		zooms[req.URL.Query().Get("key")] = req.URL.Query().Get("zoom")
+		resp.Write([]byte(responseForReverse))
+	}))
+	defer ts.Close()
+
+	first, second := Geocoder("key1", 1, ts.URL+"/"), Geocoder("key2", 2, ts.URL+"/")
+	for _, g := range []geo.Geocoder{first, second} {
+		if _, err := g.ReverseGeocode(48.1453641, 11.5582083); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+	}
+	if zooms["key1"] != "1" || zooms["key2"] != "2" {
+		t.Errorf("Expected zoom 1 for key1 and 2 for key2, got %v", zooms)
 	}
 }
 
@@ -4,6 +4,8 @@ package mapbox
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/codingsince1985/geo-golang"
@@ -15,6 +17,7 @@ type (
 		Features []struct {
 			PlaceName string `json:"place_name"`
 			Center    [2]float64
+			BBox      []float64       `json:"bbox"`    // west, south, east, north
 			Text      string          `json:"text"`    // usually street name
 			Address   json.RawMessage `json:"address"` // potentially house number
 			Context   []struct {
@@ -33,13 +36,34 @@ const (
 	mapboxPrefixPostcode = "postcode"
 	mapboxPrefixState    = "region"
 	mapboxPrefixCountry  = "country"
+	maxBatchSize         = 50
 )
 
-// Geocoder constructs Mapbox geocoder
+type mapboxGeocoder struct {
+	geo.HTTPGeocoder
+	url baseURL
+}
+
+func init() {
+	geo.Register("mapbox", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		g := Geocoder(o.Key, o.BaseURLs()...).(mapboxGeocoder)
+		g.HTTPGeocoder = o.Apply(g.HTTPGeocoder, "language", "country")
+		return g, nil
+	})
+}
+
+// Geocoder constructs Mapbox geocoder, which is also a geo.BatchGeocoder using the batch endpoint of mapbox.places-permanent
 func Geocoder(token string, baseURLs ...string) geo.Geocoder {
-	return geo.HTTPGeocoder{
-		EndpointBuilder:       baseURL(getURL(token, baseURLs...)),
-		ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
+	b := baseURL(getURL(token, baseURLs...))
+	return mapboxGeocoder{
+		HTTPGeocoder: geo.HTTPGeocoder{
+			EndpointBuilder:       b,
+			ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
+		},
+		url: b,
 	}
 }
 
@@ -50,12 +74,109 @@ func getURL(token string, baseURLs ...string) string {
 	return "https://api.mapbox.com/geocoding/v5/mapbox.places/*.json?limit=1&access_token=" + token
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "mapbox",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	MaxBatchSize:       maxBatchSize,
+	RateLimit:          10,
+	// results of mapbox.places, the temporary geocoding endpoint, must not be stored
+	Terms: geo.Terms{
+		Attribution: "© Mapbox © OpenStreetMap contributors",
+		Licence:     "Mapbox Terms of Service",
+		NoStore:     true,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string { return strings.Replace(string(b), "*", address, 1) }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
 	return strings.Replace(string(b), "*", fmt.Sprintf("%+f,%+f", l.Lng, l.Lat), 1)
 }
 
+func (b baseURL) batchURL(queries []string) string {
+	permanent := strings.Replace(string(b), "mapbox.places/", "mapbox.places-permanent/", 1)
+	return strings.Replace(permanent, "*", strings.Join(queries, ";"), 1)
+}
+
+// MaxBatchSize returns the maximum number of queries in a batch request
+func (g mapboxGeocoder) MaxBatchSize() int { return maxBatchSize }
+
+// BatchGeocode returns locations for addresses
+func (g mapboxGeocoder) BatchGeocode(addresses []string) ([]*geo.Location, error) {
+	queries := make([]string, len(addresses))
+	for i, address := range addresses {
+		queries[i] = url.PathEscape(address)
+	}
+	responses, err := g.batch(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]*geo.Location, len(responses))
+	for i := range responses {
+		if locations[i], err = responses[i].Location(); err != nil {
+			return nil, err
+		}
+	}
+	return locations, nil
+}
+
+// BatchReverseGeocode returns addresses for locations
+func (g mapboxGeocoder) BatchReverseGeocode(locations []geo.Location) ([]*geo.Address, error) {
+	queries := make([]string, len(locations))
+	for i, l := range locations {
+		queries[i] = fmt.Sprintf("%+f,%+f", l.Lng, l.Lat)
+	}
+	responses, err := g.batch(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]*geo.Address, len(responses))
+	for i := range responses {
+		if addresses[i], err = responses[i].Address(); err != nil {
+			return nil, err
+		}
+		if addresses[i] != nil {
+			addresses[i].NormalizeCountry()
+		}
+	}
+	return addresses, nil
+}
+
+func (g mapboxGeocoder) batch(queries []string) ([]geocodeResponse, error) {
+	if len(queries) > maxBatchSize {
+		return nil, fmt.Errorf("batch of %d queries exceeds %d", len(queries), maxBatchSize)
+	}
+	req, err := http.NewRequest(http.MethodGet, g.url.batchURL(queries), nil)
+	if err != nil {
+		return nil, err
+	}
+	var body json.RawMessage
+	if err := g.Do(req, &body); err != nil {
+		return nil, err
+	}
+
+	// a batch of one query is answered with a single response instead of an array
+	var responses []geocodeResponse
+	if len(queries) == 1 {
+		responses = make([]geocodeResponse, 1)
+		err = json.Unmarshal(body, &responses[0])
+	} else {
+		err = json.Unmarshal(body, &responses)
+	}
+	return responses, err
+}
+
 func (r *geocodeResponse) Location() (*geo.Location, error) {
 	if len(r.Features) == 0 {
 		// error in response
@@ -73,6 +194,14 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.Features) == 0 || len(r.Features[0].BBox) != 4 {
+		return nil
+	}
+	b := r.Features[0].BBox
+	return &geo.BoundingBox{South: b[1], West: b[0], North: b[3], East: b[2]}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if len(r.Features) == 0 {
 		// error in response
@@ -24,6 +24,25 @@ func TestGeocode(t *testing.T) {
 	assert.Equal(t, geo.Location{Lat: -37.813754, Lng: 144.971756}, *location)
 }
 
+func TestGeocodeResultAcrossAntimeridian(t *testing.T) {
+	ts := testServer(`{"type": "FeatureCollection", "features": [{"id": "country.8716", "type": "Feature", "text": "Fiji", "place_name": "Fiji",
+  "bbox": [177.0, -21.0, -178.2, -12.4], "center": [178.0, -17.7]}]}`)
+	defer ts.Close()
+
+	geocoder := mapbox.Geocoder(token, ts.URL+"/").(geo.ResultGeocoder)
+	result, err := geocoder.GeocodeResult("Fiji")
+	assert.NoError(t, err)
+	assert.Equal(t, &geo.BoundingBox{South: -21, West: 177, North: -12.4, East: -178.2}, result.BoundingBox)
+	assert.True(t, result.BoundingBox.CrossesAntimeridian())
+	assert.True(t, result.BoundingBox.Contains(geo.Location{Lat: -16.8, Lng: -179.9}))
+
+	ts = testServer(response1)
+	defer ts.Close()
+	result, err = mapbox.Geocoder(token, ts.URL+"/").(geo.ResultGeocoder).GeocodeResult("60 Collins St, Melbourne VIC 3000")
+	assert.NoError(t, err)
+	assert.Nil(t, result.BoundingBox)
+}
+
 func TestReverseGeocode(t *testing.T) {
 	ts := testServer(response2)
 	defer ts.Close()
@@ -55,6 +74,34 @@ func TestReverseGeocodeWithNoResult(t *testing.T) {
 	assert.Nil(t, addr)
 }
 
+func TestBatchGeocode(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		path = req.URL.EscapedPath()
+		resp.Write([]byte("[" + response1 + "," + response3 + "]"))
+	}))
+	defer ts.Close()
+
+	geocoder := mapbox.Geocoder(token, ts.URL+"/mapbox.places/*.json").(geo.BatchGeocoder)
+	locations, err := geocoder.BatchGeocode([]string{"60 Collins St, Melbourne VIC 3000", "Nowhere"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/mapbox.places-permanent/60%20Collins%20St%2C%20Melbourne%20VIC%203000;Nowhere.json", path)
+	assert.Len(t, locations, 2)
+	assert.Equal(t, geo.Location{Lat: -37.813754, Lng: 144.971756}, *locations[0])
+	assert.Nil(t, locations[1])
+}
+
+func TestBatchReverseGeocode(t *testing.T) {
+	ts := testServer(response2)
+	defer ts.Close()
+
+	geocoder := mapbox.Geocoder(token, ts.URL+"/").(geo.BatchGeocoder)
+	addresses, err := geocoder.BatchReverseGeocode([]geo.Location{{Lat: -37.813754, Lng: 144.971756}})
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 1)
+	assert.True(t, strings.Index(addresses[0].FormattedAddress, "60 Collins St") >= 0)
+}
+
 func testServer(response string) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 		resp.Write([]byte(response))
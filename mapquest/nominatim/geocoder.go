@@ -10,22 +10,29 @@ import (
 )
 
 type (
-	baseURL string
+	baseURL struct{ url, key string }
 
 	geocodeResponse struct {
 		DisplayName     string `json:"display_name"`
 		Lat, Lon, Error string
-		Addr            osm.Address `json:"address"`
+		Addr            osm.Address     `json:"address"`
+		BBox            osm.BoundingBox `json:"boundingbox"`
 	}
 )
 
-var key string
+func init() {
+	geo.Register("mapquest-nominatim", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "accept-language", "countrycodes"), nil
+	})
+}
 
 // Geocoder constructs MapRequest Nominatim geocoder
 func Geocoder(k string, baseURLs ...string) geo.Geocoder {
-	key = k
 	return geo.HTTPGeocoder{
-		EndpointBuilder:       baseURL(getURL(baseURLs...)),
+		EndpointBuilder:       baseURL{getURL(baseURLs...), k},
 		ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
 	}
 }
@@ -37,12 +44,31 @@ func getURL(baseURLs ...string) string {
 	return "http://open.mapquestapi.com/nominatim/v1/"
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "mapquest-nominatim",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: osm.Attribution,
+		Licence:     osm.Licence,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
-	return string(b) + "search.php?key=" + key + "&format=json&limit=1&q=" + address
+	return b.url + "search.php?key=" + b.key + "&format=json&limit=1&q=" + address
 }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
-	return string(b) + "reverse.php?key=" + key + fmt.Sprintf("&format=json&lat=%f&lon=%f", l.Lat, l.Lng)
+	return b.url + "reverse.php?key=" + b.key + fmt.Sprintf("&format=json&lat=%f&lon=%f", l.Lat, l.Lng)
 }
 
 func (r *geocodeResponse) Location() (*geo.Location, error) {
@@ -56,6 +82,8 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox { return r.BBox.BoundingBox() }
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Error != "" {
 		return nil, fmt.Errorf("reverse geocode error: %s", r.Error)
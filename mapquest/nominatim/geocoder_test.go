@@ -53,6 +53,22 @@ func TestReverseGeocodeWithNoResult(t *testing.T) {
 	assert.Nil(t, addr)
 }
 
+func TestGeocodersKeepTheirKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		keys = append(keys, req.URL.Query().Get("key"))
+		resp.Write([]byte(response2))
+	}))
+	defer ts.Close()
+
+	first, second := nominatim.Geocoder("key1", ts.URL+"/"), nominatim.Geocoder("key2", ts.URL+"/")
+	for _, g := range []geo.Geocoder{first, second} {
+		_, err := g.ReverseGeocode(-37.8137433689794, 144.971745104488)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"key1", "key2"}, keys)
+}
+
 func testServer(response string) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 		resp.Write([]byte(response))
@@ -29,6 +29,15 @@ type (
 	}
 )
 
+func init() {
+	geo.Register("mapquest-open", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "", ""), nil
+	})
+}
+
 // Geocoder constructs MapRequest Open geocoder
 func Geocoder(key string, baseURLs ...string) geo.Geocoder {
 
@@ -45,6 +54,23 @@ func getURL(key string, baseURLs ...string) string {
 	return "http://open.mapquestapi.com/geocoding/v1/*?key=" + key + "&location="
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "mapquest-open",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: "© MapQuest",
+		Licence:     "MapQuest Terms of Use",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	return strings.Replace(string(b), "*", "address", 1) + address
 }
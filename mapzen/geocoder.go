@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	geo "github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/osm"
 )
 
 type (
@@ -36,6 +37,15 @@ type (
 	}
 )
 
+func init() {
+	geo.Register("mapzen", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "lang", "boundary.country"), nil
+	})
+}
+
 // Geocoder constructs Mapzen geocoder
 func Geocoder(key string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -51,6 +61,25 @@ func getUrl(key string, baseURLs ...string) string {
 	return "https://search.mapzen.com/v1/*" + "&api_key=" + key
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "mapzen",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: osm.Attribution,
+		Licence:     osm.Licence,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	params := fmt.Sprintf("search?size=%d&text=%s", 1, address)
 	return strings.Replace(string(b), "*", params, 1)
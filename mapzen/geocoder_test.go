@@ -40,7 +40,7 @@ func TestReverseGeocode(t *testing.T) {
 	defer ts.Close()
 
 	house := "1109"
-	code := "USA"
+	code := "US"
 	state := "Virginia"
 	lat := 38.886665
 	lng := -77.094733
@@ -0,0 +1,130 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// letters of the 100km squares of MGRS, columns cycling every 3 zones and rows every 2
+var (
+	mgrsColumns = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	mgrsRows    = [2]string{"ABCDEFGHJKLMNPQRSTUV", "FGHJKLMNPQRSTUVABCDE"}
+)
+
+var mgrsPattern = regexp.MustCompile(`^(\d{1,2})\s*([C-HJ-NP-X])\s*([A-HJ-NP-Z])([A-HJ-NP-V])\s*(\d{0,5})\s*(\d{0,5})$`)
+
+// MGRS is a grid reference of the Military Grid Reference System, a 100km square of a UTM zone and latitude band
+// with the easting and northing in meters within it
+type MGRS struct {
+	Zone              int
+	Band              byte
+	Square            string // column and row letters of the 100km square
+	Easting, Northing float64
+}
+
+// MGRS returns the grid reference of u
+func (u UTM) MGRS() MGRS {
+	column := int(math.Floor(u.Easting / 100000))
+	row := int(math.Floor(u.Northing/100000)) % 20
+	return MGRS{
+		Zone:     u.Zone,
+		Band:     u.Band,
+		Square:   string([]byte{mgrsColumns[(u.Zone-1)%3][column-1], mgrsRows[(u.Zone-1)%2][row]}),
+		Easting:  math.Mod(u.Easting, 100000),
+		Northing: math.Mod(u.Northing, 100000),
+	}
+}
+
+// LocationToMGRS returns the grid reference of l, between 80°S and 84°N
+func LocationToMGRS(l Location) (MGRS, error) {
+	u, err := LocationToUTM(l)
+	if err != nil {
+		return MGRS{}, err
+	}
+	return u.MGRS(), nil
+}
+
+// ParseMGRS returns the grid reference in s, with or without spaces, such as "55H CU 20704 12735" or "55HCU2070412735".
+// Fewer digits are a larger area, whose south west corner is returned
+func ParseMGRS(s string) (MGRS, error) {
+	m := mgrsPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return MGRS{}, fmt.Errorf("%q is not an MGRS grid reference", s)
+	}
+	digits := m[5] + m[6]
+	if len(digits)%2 != 0 {
+		return MGRS{}, fmt.Errorf("MGRS grid reference %q has an odd number of digits", s)
+	}
+	zone, _ := strconv.Atoi(m[1])
+	g := MGRS{Zone: zone, Band: m[2][0], Square: m[3] + m[4]}
+	if half := len(digits) / 2; half > 0 {
+		scale := math.Pow(10, float64(5-half))
+		e, _ := strconv.Atoi(digits[:half])
+		n, _ := strconv.Atoi(digits[half:])
+		g.Easting, g.Northing = float64(e)*scale, float64(n)*scale
+	}
+	if zone < 1 || zone > 60 {
+		return MGRS{}, fmt.Errorf("invalid MGRS zone %d", zone)
+	}
+	if !strings.Contains(mgrsColumns[(zone-1)%3], m[3]) {
+		return MGRS{}, fmt.Errorf("invalid MGRS column %s for zone %d", m[3], zone)
+	}
+	return g, nil
+}
+
+// UTM returns the UTM coordinates of g, resolving which 2000km cycle of rows it is in from its latitude band
+func (g MGRS) UTM() (UTM, error) {
+	if g.Zone < 1 || g.Zone > 60 || len(g.Square) != 2 {
+		return UTM{}, fmt.Errorf("invalid MGRS grid reference %v", g)
+	}
+	column := strings.IndexByte(mgrsColumns[(g.Zone-1)%3], g.Square[0])
+	row := strings.IndexByte(mgrsRows[(g.Zone-1)%2], g.Square[1])
+	band := strings.IndexByte(latitudeBands, g.Band)
+	if column < 0 || row < 0 || band < 0 {
+		return UTM{}, fmt.Errorf("invalid MGRS grid reference %v", g)
+	}
+
+	// the least northing of the bottom of the latitude band, on the central meridian in the north and on the edge
+	// of the zone in the south, floored to 100km
+	lat := float64(band-10) * 8
+	_, middle := transverseMercator(Location{Lat: lat}, 0)
+	_, edge := transverseMercator(Location{Lat: lat, Lng: 3}, 0)
+	bottom := math.Min(middle, edge)
+	if lat < 0 {
+		bottom += utmFalseNorthing
+	}
+	floor := math.Floor(bottom/100000) * 100000
+
+	easting := float64(column+1)*100000 + g.Easting
+	northing := float64(row)*100000 + g.Northing
+	for northing < floor {
+		northing += 2000000
+	}
+	u := UTM{Zone: g.Zone, Band: g.Band, Easting: easting, Northing: northing}
+	return u, u.Validate()
+}
+
+// Location returns the south west corner of g
+func (g MGRS) Location() (Location, error) {
+	u, err := g.UTM()
+	if err != nil {
+		return Location{}, err
+	}
+	return u.Location(), nil
+}
+
+// Format returns g with digits for each of easting and northing, from 0 for the 100km square to 5 for 1m
+func (g MGRS) Format(digits int) string {
+	scale := math.Pow(10, float64(5-digits))
+	if digits == 0 {
+		return fmt.Sprintf("%d%c %s", g.Zone, g.Band, g.Square)
+	}
+	return fmt.Sprintf("%d%c %s %0*d %0*d", g.Zone, g.Band, g.Square,
+		digits, int(math.Floor(g.Easting/scale)), digits, int(math.Floor(g.Northing/scale)))
+}
+
+// String returns g to the meter, such as "55H CU 20704 12735"
+func (g MGRS) String() string { return g.Format(5) }
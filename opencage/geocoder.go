@@ -17,6 +17,14 @@ type (
 			Formatted  string
 			Geometry   geo.Location
 			Components osm.Address
+			Bounds     *struct {
+				Northeast, Southwest geo.Location
+			}
+			Annotations struct {
+				Timezone struct {
+					Name string
+				}
+			}
 		}
 		Status struct {
 			Code    int
@@ -25,6 +33,15 @@ type (
 	}
 )
 
+func init() {
+	geo.Register("opencage", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "language", "countrycode"), nil
+	})
+}
+
 // Geocoder constructs OpenCage geocoder
 func Geocoder(key string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -40,6 +57,25 @@ func getURL(key string, baseURLs ...string) string {
 	return "http://api.opencagedata.com/geocode/v1/json?key=" + key + "&q="
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "opencage",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	RateLimit:          1,
+	Terms: geo.Terms{
+		Attribution: osm.Attribution,
+		Licence:     osm.Licence,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string { return string(b) + address }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
@@ -60,6 +96,21 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.Results) == 0 || r.Results[0].Bounds == nil {
+		return nil
+	}
+	b := r.Results[0].Bounds
+	return &geo.BoundingBox{South: b.Southwest.Lat, West: b.Southwest.Lng, North: b.Northeast.Lat, East: b.Northeast.Lng}
+}
+
+func (r *geocodeResponse) Timezone() string {
+	if len(r.Results) == 0 {
+		return ""
+	}
+	return r.Results[0].Annotations.Timezone.Name
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Status.Code >= 400 {
 		return nil, fmt.Errorf("geocoding error: %s", r.Status.Message)
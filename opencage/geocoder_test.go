@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/codingsince1985/geo-golang"
 	"github.com/codingsince1985/geo-golang/opencage"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,6 +29,17 @@ func TestGeocode(t *testing.T) {
 	assert.InDelta(t, 144.9665563, location.Lng, locDelta)
 }
 
+func TestGeocodeResult(t *testing.T) {
+	ts := testServer(response1)
+	defer ts.Close()
+
+	geocoder := opencage.Geocoder(key, ts.URL+"/").(geo.ResultGeocoder)
+	result, err := geocoder.GeocodeResult("60 Collins St, Melbourne VIC 3000")
+	assert.Nil(t, err)
+	assert.Equal(t, &geo.BoundingBox{South: -37.8169249, West: 144.9617036, North: -37.8162553, East: 144.9640149}, result.BoundingBox)
+	assert.Equal(t, "Australia/Melbourne", result.Timezone)
+}
+
 func TestReverseGeocode(t *testing.T) {
 	ts := testServer(response2)
 	defer ts.Close()
@@ -36,6 +48,7 @@ func TestReverseGeocode(t *testing.T) {
 	address, err := geocoder.ReverseGeocode(-37.8154176, 144.9665563)
 	assert.NoError(t, err)
 	assert.True(t, strings.Index(address.FormattedAddress, "Collins St") > 0)
+	assert.Equal(t, "Australia/Melbourne", address.Timezone)
 }
 
 func TestReverseGeocodeWithNoResult(t *testing.T) {
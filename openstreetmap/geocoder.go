@@ -16,10 +16,21 @@ type (
 		Lat         string
 		Lon         string
 		Error       string
-		Addr        osm.Address `json:"address"`
+		Addr        osm.Address     `json:"address"`
+		BBox        osm.BoundingBox `json:"boundingbox"`
 	}
 )
 
+func init() {
+	geo.Register("osm", func(o geo.Options) (geo.Geocoder, error) {
+		g := Geocoder()
+		if o.BaseURL != "" {
+			g = GeocoderWithURL(o.BaseURL)
+		}
+		return o.Apply(g.(geo.HTTPGeocoder), "accept-language", "countrycodes"), nil
+	})
+}
+
 // Geocoder constructs OpenStreetMap geocoder
 func Geocoder() geo.Geocoder { return GeocoderWithURL("https://nominatim.openstreetmap.org/") }
 
@@ -31,6 +42,26 @@ func GeocoderWithURL(nominatimURL string) geo.Geocoder {
 	}
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "osm",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	RateLimit:          1,
+	Terms: geo.Terms{
+		Attribution: osm.Attribution,
+		Licence:     osm.Licence,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	return string(b) + "search?format=json&limit=1&q=" + address
 }
@@ -53,6 +84,8 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox { return r.BBox.BoundingBox() }
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Error != "" {
 		return nil, fmt.Errorf("reverse geocoding error: %s", r.Error)
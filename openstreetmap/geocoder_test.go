@@ -21,6 +21,16 @@ func TestGeocode(t *testing.T) {
 	assert.Equal(t, geo.Location{Lat: -37.8157915, Lng: 144.9656171}, *location)
 }
 
+func TestGeocodeResult(t *testing.T) {
+	ts := testServer(response1)
+	defer ts.Close()
+
+	geocoder := openstreetmap.GeocoderWithURL(ts.URL + "/").(geo.ResultGeocoder)
+	result, err := geocoder.GeocodeResult("60 Collins St, Melbourne VIC 3000")
+	assert.Nil(t, err)
+	assert.Equal(t, &geo.BoundingBox{South: -37.8162553, West: 144.9640149, North: -37.815533, East: 144.9665099}, result.BoundingBox)
+}
+
 func TestReverseGeocode(t *testing.T) {
 	ts := testServer(response2)
 	defer ts.Close()
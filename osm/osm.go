@@ -2,6 +2,14 @@
 // and some helper functions to reduce code repetition across specific client implementations.
 package osm
 
+import "github.com/codingsince1985/geo-golang"
+
+// Attribution and Licence of OpenStreetMap data, which services built on it must display
+const (
+	Attribution = "© OpenStreetMap contributors"
+	Licence     = "ODbL-1.0"
+)
+
 // Address contains address fields specific to OpenStreetMap
 type Address struct {
 	HouseNumber   string `json:"house_number"`
@@ -24,6 +32,22 @@ type Address struct {
 	Postcode      string `json:"postcode"`
 }
 
+// BoundingBox is the extent of a place as Nominatim returns it: south, north, west, east
+type BoundingBox []string
+
+// BoundingBox converts b, or returns nil if b is incomplete
+func (b BoundingBox) BoundingBox() *geo.BoundingBox {
+	if len(b) != 4 {
+		return nil
+	}
+	return &geo.BoundingBox{
+		South: geo.ParseFloat(b[0]),
+		North: geo.ParseFloat(b[1]),
+		West:  geo.ParseFloat(b[2]),
+		East:  geo.ParseFloat(b[3]),
+	}
+}
+
 // Locality checks different fields for the locality name
 func (a Address) Locality() string {
 	var locality string
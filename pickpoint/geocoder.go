@@ -10,23 +10,30 @@ import (
 )
 
 type (
-	baseURL         string
+	baseURL         struct{ url, key string }
 	geocodeResponse struct {
 		DisplayName string `json:"display_name"`
 		Lat         string
 		Lon         string
 		Error       string
-		Addr        osm.Address `json:"address"`
+		Addr        osm.Address     `json:"address"`
+		BBox        osm.BoundingBox `json:"boundingbox"`
 	}
 )
 
-var key string
+func init() {
+	geo.Register("pickpoint", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "accept-language", "countrycodes"), nil
+	})
+}
 
 // Geocoder constructs PickPoint geocoder
 func Geocoder(apiKey string, baseURLs ...string) geo.Geocoder {
-	key = apiKey
 	return geo.HTTPGeocoder{
-		EndpointBuilder:       baseURL(getURL(baseURLs...)),
+		EndpointBuilder:       baseURL{getURL(baseURLs...), apiKey},
 		ResponseParserFactory: func() geo.ResponseParser { return &geocodeResponse{} },
 	}
 }
@@ -38,12 +45,31 @@ func getURL(baseURLs ...string) string {
 	return "https://api.pickpoint.io/v1"
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "pickpoint",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: osm.Attribution,
+		Licence:     osm.Licence,
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
-	return string(b) + fmt.Sprintf("/forward?key=%s&limit=1&q=%s", key, address)
+	return b.url + fmt.Sprintf("/forward?key=%s&limit=1&q=%s", b.key, address)
 }
 
 func (b baseURL) ReverseGeocodeURL(l geo.Location) string {
-	return string(b) + fmt.Sprintf("/reverse?key=%s&lat=%f&lon=%f", key, l.Lat, l.Lng)
+	return b.url + fmt.Sprintf("/reverse?key=%s&lat=%f&lon=%f", b.key, l.Lat, l.Lng)
 }
 
 func (r *geocodeResponse) Location() (*geo.Location, error) {
@@ -60,6 +86,8 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	}, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox { return r.BBox.BoundingBox() }
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Error != "" {
 		return nil, fmt.Errorf("reverse geocoding error: %s", r.Error)
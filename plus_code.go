@@ -0,0 +1,168 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Open Location Code alphabet, separator and padding
+const (
+	plusCodeAlphabet  = "23456789CFGHJMPQRVWX"
+	plusCodeSeparator = '+'
+	plusCodePadding   = '0'
+	plusCodePairs     = 10 // digits encoded as pairs of latitude and longitude, the rest in a 5 by 4 grid
+	plusCodeMaxLength = 15
+)
+
+// precisions of the Open Location Code integer encoding, per degree at full length
+const (
+	plusCodeLatPrecision = 25000000 // 20^5 * 5^5
+	plusCodeLngPrecision = 8192000  // 20^5 * 4^5
+)
+
+// EncodePlusCode returns the full Plus Code of length digits, 2 to 8 in pairs, or 10 and above, containing l,
+// such as "4RJ65WCX+FM"
+func EncodePlusCode(l Location, length int) (string, error) {
+	if length < 2 || length < plusCodePairs && length%2 == 1 || length > plusCodeMaxLength {
+		return "", fmt.Errorf("invalid Plus Code length %d", length)
+	}
+	lat := math.Round((ClampLatitude(l.Lat) + 90) * plusCodeLatPrecision)
+	lng := math.Round((WrapLongitude(l.Lng) + 180) * plusCodeLngPrecision)
+	// the north pole is in the row below
+	if lat >= 180*plusCodeLatPrecision {
+		lat = 180*plusCodeLatPrecision - 1
+	}
+	latInt, lngInt := int64(lat), int64(lng)
+
+	code := make([]byte, plusCodeMaxLength)
+	for i := plusCodeMaxLength - 1; i >= plusCodePairs; i-- {
+		code[i] = plusCodeAlphabet[latInt%5*4+lngInt%4]
+		latInt, lngInt = latInt/5, lngInt/4
+	}
+	for i := plusCodePairs - 2; i >= 0; i -= 2 {
+		code[i] = plusCodeAlphabet[latInt%20]
+		code[i+1] = plusCodeAlphabet[lngInt%20]
+		latInt, lngInt = latInt/20, lngInt/20
+	}
+
+	digits := string(code[:length])
+	if length < 8 {
+		digits += strings.Repeat(string(plusCodePadding), 8-length)
+	}
+	return digits[:8] + string(plusCodeSeparator) + digits[8:], nil
+}
+
+// DecodePlusCode returns the area of a full Plus Code
+func DecodePlusCode(code string) (BoundingBox, error) {
+	code = strings.TrimSpace(code)
+	digits, err := plusCodeDigits(code)
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	if len(digits) < 2 || strings.IndexByte(plusCodeAlphabet, digits[0]) > 8 || strings.IndexByte(plusCodeAlphabet, digits[1]) > 17 {
+		return BoundingBox{}, fmt.Errorf("%q is not a full Plus Code", code)
+	}
+	if strings.IndexByte(code, plusCodeSeparator) != 8 {
+		return BoundingBox{}, fmt.Errorf("%q is a short Plus Code", code)
+	}
+
+	var lat, lng int64
+	latPlace, lngPlace := int64(plusCodeLatPrecision)*400, int64(plusCodeLngPrecision)*400
+	for i := 0; i < len(digits) && i < plusCodePairs; i += 2 {
+		latPlace, lngPlace = latPlace/20, lngPlace/20
+		lat += int64(strings.IndexByte(plusCodeAlphabet, digits[i])) * latPlace
+		lng += int64(strings.IndexByte(plusCodeAlphabet, digits[i+1])) * lngPlace
+	}
+	for i := plusCodePairs; i < len(digits) && i < plusCodeMaxLength; i++ {
+		d := int64(strings.IndexByte(plusCodeAlphabet, digits[i]))
+		latPlace, lngPlace = latPlace/5, lngPlace/4
+		lat += d / 4 * latPlace
+		lng += d % 4 * lngPlace
+	}
+	return BoundingBox{
+		South: float64(lat)/plusCodeLatPrecision - 90,
+		West:  float64(lng)/plusCodeLngPrecision - 180,
+		North: float64(lat+latPlace)/plusCodeLatPrecision - 90,
+		East:  float64(lng+lngPlace)/plusCodeLngPrecision - 180,
+	}, nil
+}
+
+// plusCodeDigits returns the digits of code without separator and padding, or an error if code is malformed
+func plusCodeDigits(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	if sep < 0 || sep != strings.LastIndexByte(code, plusCodeSeparator) || sep > 8 || sep%2 == 1 {
+		return "", fmt.Errorf("%q is not a Plus Code", code)
+	}
+	if len(code)-sep-1 == 1 {
+		return "", fmt.Errorf("%q is not a Plus Code: single digit after %c", code, plusCodeSeparator)
+	}
+
+	if pad := strings.IndexByte(code, plusCodePadding); pad >= 0 {
+		// padding is only in full codes, in pairs, up to the separator, with nothing after it
+		padding := code[pad:sep]
+		if pad == 0 || pad%2 == 1 || sep != 8 || sep != len(code)-1 ||
+			strings.Trim(padding, string(plusCodePadding)) != "" || len(padding)%2 == 1 {
+			return "", fmt.Errorf("%q is not a Plus Code: invalid padding", code)
+		}
+		code = code[:pad]
+	}
+
+	digits := strings.Replace(code, string(plusCodeSeparator), "", 1)
+	for _, c := range digits {
+		if !strings.ContainsRune(plusCodeAlphabet, c) {
+			return "", fmt.Errorf("%q is not a Plus Code: unexpected %q", code, c)
+		}
+	}
+	return digits, nil
+}
+
+// IsShortPlusCode tells whether code is a Plus Code with its first digits left out, such as "WCX+FM",
+// which RecoverPlusCode completes from a nearby location
+func IsShortPlusCode(code string) bool {
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	_, err := plusCodeDigits(code)
+	return err == nil && sep >= 0 && sep < 8
+}
+
+// RecoverPlusCode returns the full Plus Code nearest reference of the short code,
+// or code itself in upper case if it is already full
+func RecoverPlusCode(code string, reference Location) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, err := DecodePlusCode(code); err == nil {
+		return code, nil
+	}
+	if !IsShortPlusCode(code) {
+		return "", fmt.Errorf("%q is not a short Plus Code", code)
+	}
+
+	// the digits left out are those of reference, adjusted to the nearest area of their size
+	missing := 8 - strings.IndexByte(code, plusCodeSeparator)
+	resolution := math.Pow(20, 2-float64(missing/2))
+	reference.Lat, reference.Lng = ClampLatitude(reference.Lat), WrapLongitude(reference.Lng)
+	prefix, err := EncodePlusCode(reference, plusCodePairs)
+	if err != nil {
+		return "", err
+	}
+	recovered, err := DecodePlusCode(prefix[:missing] + code)
+	if err != nil {
+		return "", err
+	}
+
+	c := recovered.Center()
+	half := resolution / 2
+	switch {
+	case reference.Lat+half < c.Lat && c.Lat-resolution >= -90:
+		c.Lat -= resolution
+	case reference.Lat-half > c.Lat && c.Lat+resolution <= 90:
+		c.Lat += resolution
+	}
+	switch {
+	case reference.Lng+half < c.Lng:
+		c.Lng -= resolution
+	case reference.Lng-half > c.Lng:
+		c.Lng += resolution
+	}
+	return EncodePlusCode(c, len(strings.Replace(prefix[:missing]+code, string(plusCodeSeparator), "", 1)))
+}
@@ -0,0 +1,77 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePlusCode(t *testing.T) {
+	for _, c := range []struct {
+		l      geo.Location
+		length int
+		code   string
+	}{
+		{geo.Location{Lat: 20.375, Lng: 2.775}, 6, "7FG49Q00+"},
+		{geo.Location{Lat: 20.3700625, Lng: 2.7821875}, 10, "7FG49QCJ+2V"},
+		{geo.Location{Lat: 20.3701125, Lng: 2.782234375}, 11, "7FG49QCJ+2VX"},
+		{geo.Location{Lat: 47.0000625, Lng: 8.0000625}, 10, "8FVC2222+22"},
+		{geo.Location{Lat: -41.2730625, Lng: 174.7859375}, 10, "4VCPPQGP+Q9"},
+		{geo.Location{Lat: 90, Lng: 1}, 4, "CFX30000+"},
+		{geo.Location{Lat: 1, Lng: 180}, 4, "62H20000+"},
+	} {
+		code, err := geo.EncodePlusCode(c.l, c.length)
+		assert.NoError(t, err)
+		assert.Equal(t, c.code, code)
+	}
+
+	for _, length := range []int{0, 1, 3, 9, 16} {
+		_, err := geo.EncodePlusCode(geo.Location{}, length)
+		assert.Error(t, err, length)
+	}
+}
+
+func TestDecodePlusCode(t *testing.T) {
+	b, err := geo.DecodePlusCode("7FG49QCJ+2V")
+	assert.NoError(t, err)
+	assert.InDelta(t, 20.37, b.South, 1e-9)
+	assert.InDelta(t, 2.782125, b.West, 1e-9)
+	assert.InDelta(t, 20.370125, b.North, 1e-9)
+	assert.InDelta(t, 2.78225, b.East, 1e-9)
+
+	b, err = geo.DecodePlusCode("7fg49q00+")
+	assert.NoError(t, err)
+	assert.InDelta(t, 20.35, b.South, 1e-9)
+	assert.InDelta(t, 2.75, b.West, 1e-9)
+	assert.InDelta(t, 20.4, b.North, 1e-9)
+	assert.InDelta(t, 2.8, b.East, 1e-9)
+
+	for _, code := range []string{"", "7FG49QCJ2V", "9QCJ+2V", "7FG49Q00+2V", "7FG4900Q+", "7FG49QCJ+2", "7FG49QCJ+2I", "F2G49QCJ+2V"} {
+		_, err := geo.DecodePlusCode(code)
+		assert.Error(t, err, code)
+	}
+}
+
+func TestRecoverPlusCode(t *testing.T) {
+	for _, c := range []struct {
+		short     string
+		reference geo.Location
+		code      string
+	}{
+		{"CWC8+R9", geo.Location{Lat: 37.4, Lng: -122.1}, "849VCWC8+R9"},
+		{"9QCJ+2V", geo.Location{Lat: 20.4, Lng: 2.8}, "7FG49QCJ+2V"},
+		// nearer across a boundary of the digits left out
+		{"2222+22", geo.Location{Lat: 46.99, Lng: 7.99}, "8FVC2222+22"},
+		{"7FG49QCJ+2V", geo.Location{}, "7FG49QCJ+2V"},
+	} {
+		code, err := geo.RecoverPlusCode(c.short, c.reference)
+		assert.NoError(t, err)
+		assert.Equal(t, c.code, code)
+	}
+
+	assert.True(t, geo.IsShortPlusCode("CWC8+R9"))
+	assert.False(t, geo.IsShortPlusCode("849VCWC8+R9"))
+	_, err := geo.RecoverPlusCode("Paris", geo.Location{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package postcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// Centroid is the center of the area of a postcode, with the place and the administrative areas it is in
+type Centroid struct {
+	CountryCode, Postcode, Place, State, County string
+	Location                                    geo.Location
+}
+
+// cell is a whole degree of latitude and longitude
+type cell struct{ lat, lng int }
+
+func cellOf(l geo.Location) cell {
+	return cell{lat: int(math.Floor(l.Lat)), lng: int(math.Floor(l.Lng))}
+}
+
+// Dataset is a set of postcode centroids, indexed by postcode and by location
+type Dataset struct {
+	centroids  []Centroid
+	byPostcode map[string][]int // by key
+	anyCountry map[string][]int // by compact postcode, whatever the country
+	byCell     map[cell][]int
+}
+
+// key is the index of a postcode of a country, compact so that "SW1A 2AA" and "sw1a2aa" are the same
+func key(countryCode, postcode string) string {
+	return alpha2(countryCode) + " " + compacted(postcode)
+}
+
+// NewDataset returns centroids indexed by postcode and by location
+func NewDataset(centroids []Centroid) *Dataset {
+	d := &Dataset{centroids: centroids, byPostcode: map[string][]int{}, anyCountry: map[string][]int{}, byCell: map[cell][]int{}}
+	for i, c := range centroids {
+		k := key(c.CountryCode, c.Postcode)
+		d.byPostcode[k] = append(d.byPostcode[k], i)
+		d.anyCountry[compacted(c.Postcode)] = append(d.anyCountry[compacted(c.Postcode)], i)
+		d.byCell[cellOf(c.Location)] = append(d.byCell[cellOf(c.Location)], i)
+	}
+	return d
+}
+
+// geoNamesColumns is the number of tab separated columns of the GeoNames postal codes:
+// country code, postal code, place name, admin name and code 1 to 3, latitude, longitude and accuracy
+const geoNamesColumns = 12
+
+// LoadGeoNames returns the postcode centroids of r in the format of the GeoNames postal codes,
+// such as allCountries.txt from https://download.geonames.org/export/zip/
+func LoadGeoNames(r io.Reader) (*Dataset, error) {
+	var centroids []Centroid
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		columns := strings.Split(scanner.Text(), "\t")
+		if len(columns) < geoNamesColumns-1 {
+			return nil, fmt.Errorf("line %d: %d columns, expected %d", line, len(columns), geoNamesColumns)
+		}
+		l := geo.Location{Lat: geo.ParseFloat(columns[9]), Lng: geo.ParseFloat(columns[10])}
+		if err := l.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		centroids = append(centroids, Centroid{
+			CountryCode: columns[0],
+			Postcode:    columns[1],
+			Place:       columns[2],
+			State:       columns[3],
+			County:      columns[5],
+			Location:    l,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewDataset(centroids), nil
+}
+
+// Len returns the number of centroids in d
+func (d *Dataset) Len() int { return len(d.centroids) }
+
+// Lookup returns the centroids of postcode in the country of countryCode, or in every country if it is empty
+func (d *Dataset) Lookup(countryCode, postcode string) []Centroid {
+	indices := d.anyCountry[compacted(postcode)]
+	if countryCode != "" {
+		indices = d.byPostcode[key(countryCode, postcode)]
+	}
+	var centroids []Centroid
+	for _, i := range indices {
+		centroids = append(centroids, d.centroids[i])
+	}
+	return centroids
+}
+
+// maxRings is how many rings of cells around a location Nearest searches
+const maxRings = 3
+
+// metersPerDegree is a little less than the shortest degree of latitude, that at the equator
+const metersPerDegree = 110000.0
+
+// beyondRing returns a distance in meters shorter than that from l to any location outside the cells
+// within ring rings of center, the cell of l
+func beyondRing(l geo.Location, center cell, ring int) float64 {
+	lat := math.Min(l.Lat-float64(center.lat-ring), float64(center.lat+ring+1)-l.Lat)
+	lng := math.Min(l.Lng-float64(center.lng-ring), float64(center.lng+ring+1)-l.Lng)
+	// the distance to the meridian lng degrees away, on a sphere of the shortest degree
+	toMeridian := math.Asin(math.Cos(l.Lat*math.Pi/180)*math.Sin(math.Min(lng, 90)*math.Pi/180)) * 180 / math.Pi
+	return math.Min(lat, toMeridian) * metersPerDegree
+}
+
+// Nearest returns the centroid closest to l within a few degrees, and whether there is any
+func (d *Dataset) Nearest(l geo.Location) (Centroid, bool) {
+	center := cellOf(l)
+	best, distance := -1, math.Inf(1)
+	for ring := 0; ring <= maxRings; ring++ {
+		for lat := center.lat - ring; lat <= center.lat+ring; lat++ {
+			for lng := center.lng - ring; lng <= center.lng+ring; lng++ {
+				if lat != center.lat-ring && lat != center.lat+ring && lng != center.lng-ring && lng != center.lng+ring {
+					continue
+				}
+				c := cell{lat: lat, lng: int(math.Floor(geo.WrapLongitude(float64(lng))))}
+				for _, i := range d.byCell[c] {
+					if dist := l.Distance(d.centroids[i].Location); dist < distance {
+						best, distance = i, dist
+					}
+				}
+			}
+		}
+		// cells are degree squares, narrower than high away from the equator, so that a centroid in a ring
+		// further out may still be closer than the one found
+		if best >= 0 && distance <= beyondRing(l, center, ring) {
+			break
+		}
+	}
+	if best < 0 {
+		return Centroid{}, false
+	}
+	return d.centroids[best], true
+}
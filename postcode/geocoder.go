@@ -0,0 +1,66 @@
+package postcode
+
+import (
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/address"
+)
+
+// maxReverseDistance is how far in meters a location can be from the nearest centroid to reverse geocode to it
+const maxReverseDistance = 50000
+
+type geocoder struct {
+	*Dataset
+}
+
+// Geocoder constructs a geocoder of the postcodes of d, geocoding an address to the centroid of its postcode
+// and reverse geocoding a location to the postcode of the nearest centroid
+func Geocoder(d *Dataset) geo.Geocoder { return geocoder{d} }
+
+// Geocode returns the location of the postcode in address, or of address itself as a postcode,
+// averaging the centroids of a postcode shared by several places
+func (g geocoder) Geocode(s string) (*geo.Location, error) {
+	var centroids []Centroid
+	if p, err := address.Parse(s); err == nil && p.Postcode != "" {
+		centroids = g.Lookup(p.CountryCode, p.Postcode)
+		if len(centroids) == 0 && p.CountryCode != "" && p.Confidence[address.CountryCode] < 1 {
+			// the country was guessed, maybe wrong
+			centroids = g.Lookup("", p.Postcode)
+		}
+	}
+	if len(centroids) == 0 {
+		centroids = g.Lookup("", s)
+	}
+	if len(centroids) == 0 {
+		return nil, nil
+	}
+
+	var l geo.Location
+	for _, c := range centroids {
+		if alpha2(c.CountryCode) != alpha2(centroids[0].CountryCode) {
+			// the same postcode in several countries is ambiguous
+			return nil, nil
+		}
+		l.Lat += c.Location.Lat / float64(len(centroids))
+		l.Lng += c.Location.Lng / float64(len(centroids))
+	}
+	return &l, nil
+}
+
+// ReverseGeocode returns the postcode, place and administrative areas of the centroid nearest to lat and lng
+func (g geocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	l := geo.Location{Lat: lat, Lng: lng}
+	c, ok := g.Nearest(l)
+	if !ok || l.Distance(c.Location) > maxReverseDistance {
+		return nil, nil
+	}
+	addr := &geo.Address{
+		Postcode:    c.Postcode,
+		City:        c.Place,
+		State:       c.State,
+		County:      c.County,
+		CountryCode: c.CountryCode,
+	}
+	addr.NormalizeCountry()
+	addr.FormattedAddress = address.FormatLine(*addr)
+	return addr, nil
+}
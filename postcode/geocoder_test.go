@@ -0,0 +1,101 @@
+package postcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+const geoNames = `AU	3000	Melbourne	Victoria	VIC	Melbourne		Melbourne	24600	-37.814	144.9633	4
+AU	3002	East Melbourne	Victoria	VIC	Melbourne		Melbourne	24600	-37.8167	144.9879	4
+AU	3002	Jolimont	Victoria	VIC	Melbourne		Melbourne	24600	-37.8165	144.9834	4
+US	90210	Beverly Hills	California	CA	Los Angeles	037			34.0901	-118.4065	4
+DE	10117	Berlin	Berlin	BE		00	Berlin, Stadt	11000	52.5176	13.3879	6
+FR	10117	Somewhere	Grand Est	44	Aube	10			48.3	4.08	
+NZ	6011	Wellington	Wellington		Wellington City				-41.2865	174.7762	4
+`
+
+func dataset(t *testing.T) *Dataset {
+	d, err := LoadGeoNames(strings.NewReader(geoNames))
+	assert.NoError(t, err)
+	return d
+}
+
+func TestLoadGeoNames(t *testing.T) {
+	d := dataset(t)
+	assert.Equal(t, 7, d.Len())
+	assert.Len(t, d.Lookup("AUS", "3002"), 2)
+	assert.Len(t, d.Lookup("", "10117"), 2)
+
+	_, err := LoadGeoNames(strings.NewReader("AU\t3000\tMelbourne\n"))
+	assert.Error(t, err)
+}
+
+func TestGeocode(t *testing.T) {
+	g := Geocoder(dataset(t))
+
+	location, err := g.Geocode("Flinders Street, Melbourne VIC 3000, Australia")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.814, Lng: 144.9633}, *location)
+
+	location, err = g.Geocode("90210")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: 34.0901, Lng: -118.4065}, *location)
+
+	location, err = g.Geocode("3002")
+	assert.NoError(t, err)
+	assert.InDelta(t, -37.8166, location.Lat, 1e-6)
+	assert.InDelta(t, 144.98565, location.Lng, 1e-6)
+
+	location, err = g.Geocode("10117")
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+
+	location, err = g.Geocode("Unter den Linden 1, 10117 Berlin, Germany")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: 52.5176, Lng: 13.3879}, *location)
+
+	location, err = g.Geocode("1 Lambton Quay, Wellington 6011")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -41.2865, Lng: 174.7762}, *location)
+
+	// the country guessed from the state is wrong
+	location, err = g.Geocode("Lambton Quay, Wellington VIC 6011")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -41.2865, Lng: 174.7762}, *location)
+
+	location, err = g.Geocode("12345")
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+}
+
+func TestReverseGeocode(t *testing.T) {
+	g := Geocoder(dataset(t))
+
+	addr, err := g.ReverseGeocode(-37.8136, 144.9631)
+	assert.NoError(t, err)
+	assert.Equal(t, "3000", addr.Postcode)
+	assert.Equal(t, "Melbourne", addr.City)
+	assert.Equal(t, "Victoria", addr.State)
+	assert.Equal(t, "AU", addr.CountryCode)
+	assert.Equal(t, "Australia", addr.Country)
+	assert.Contains(t, addr.FormattedAddress, "3000")
+
+	addr, err = g.ReverseGeocode(-33.8688, 151.2093)
+	assert.NoError(t, err)
+	assert.Nil(t, addr)
+}
+
+func TestNearestHighLatitude(t *testing.T) {
+	d := NewDataset([]Centroid{
+		{CountryCode: "NO", Postcode: "9600", Location: geo.Location{Lat: 71.9, Lng: 10.5}},
+		{CountryCode: "NO", Postcode: "9700", Location: geo.Location{Lat: 70.5, Lng: 12.2}},
+	})
+
+	// the second centroid, two cells east, is 65km away, the first one, a cell north, 156km
+	c, ok := d.Nearest(geo.Location{Lat: 70.5, Lng: 10.5})
+	assert.True(t, ok)
+	assert.Equal(t, "9700", c.Postcode)
+}
@@ -0,0 +1,177 @@
+// Package postcode validates postcodes by the format of their country, and geocodes them locally
+// from a dataset of postcode centroids such as the GeoNames postal codes
+package postcode
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// format is the pattern of the postcodes of a country, compact without spaces and hyphens,
+// and the function laying a compact postcode out as the country writes it
+type format struct {
+	pattern *regexp.Regexp
+	layout  func(string) string
+}
+
+// at inserts sep after the first n characters
+func at(n int, sep string) func(string) string {
+	return func(s string) string { return s[:n] + sep + s[n:] }
+}
+
+// beforeLast inserts sep before the last n characters
+func beforeLast(n int, sep string) func(string) string {
+	return func(s string) string { return s[:len(s)-n] + sep + s[len(s)-n:] }
+}
+
+// prefixed lays postcodes out as "LV-1234"
+func prefixed(prefix string) func(string) string {
+	return func(s string) string { return prefix + "-" + strings.TrimPrefix(s, prefix) }
+}
+
+func compact(pattern string, layout func(string) string) format {
+	return format{pattern: regexp.MustCompile(`^(?:` + pattern + `)$`), layout: layout}
+}
+
+// formats of the postcodes of countries with a postcode system, by ISO 3166-1 alpha-2 code
+var formats = map[string]format{
+	"AD": compact(`AD\d{3}`, nil),
+	"AR": compact(`[A-Z]?\d{4}(?:[A-Z]{3})?`, nil),
+	"AT": compact(`\d{4}`, nil),
+	"AU": compact(`\d{4}`, nil),
+	"BE": compact(`\d{4}`, nil),
+	"BG": compact(`\d{4}`, nil),
+	"BR": compact(`\d{8}`, at(5, "-")),
+	"BY": compact(`\d{6}`, nil),
+	"CA": compact(`[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z]\d[ABCEGHJ-NPRSTV-Z]\d`, at(3, " ")),
+	"CH": compact(`\d{4}`, nil),
+	"CL": compact(`\d{7}`, nil),
+	"CN": compact(`\d{6}`, nil),
+	"CO": compact(`\d{6}`, nil),
+	"CY": compact(`\d{4}`, nil),
+	"CZ": compact(`\d{5}`, at(3, " ")),
+	"DE": compact(`\d{5}`, nil),
+	"DK": compact(`\d{4}`, nil),
+	"DZ": compact(`\d{5}`, nil),
+	"EE": compact(`\d{5}`, nil),
+	"EG": compact(`\d{5}`, nil),
+	"ES": compact(`(?:0[1-9]|[1-4]\d|5[0-2])\d{3}`, nil),
+	"FI": compact(`\d{5}`, nil),
+	"FR": compact(`(?:0[1-9]|[1-8]\d|9[0-8])\d{3}`, nil),
+	"GB": compact(`GIR0AA|(?:[A-PR-UWYZ]\d\d?|[A-PR-UWYZ][A-HK-Y]\d\d?|[A-PR-UWYZ]\d[A-HJKPSTUW]|[A-PR-UWYZ][A-HK-Y]\d[ABEHMNPRV-Y])\d[ABD-HJLNP-UW-Z]{2}`, beforeLast(3, " ")),
+	"GR": compact(`\d{5}`, at(3, " ")),
+	"HR": compact(`\d{5}`, nil),
+	"HU": compact(`\d{4}`, nil),
+	"ID": compact(`\d{5}`, nil),
+	"IE": compact(`(?:[AC-FHKNPRTV-Y]\d{2}|D6W)[0-9AC-FHKNPRTV-Y]{4}`, at(3, " ")),
+	"IL": compact(`\d{5}(?:\d{2})?`, nil),
+	"IN": compact(`[1-9]\d{5}`, nil),
+	"IS": compact(`\d{3}`, nil),
+	"IT": compact(`\d{5}`, nil),
+	"JP": compact(`\d{7}`, at(3, "-")),
+	"KR": compact(`\d{5}`, nil),
+	"KZ": compact(`\d{6}`, nil),
+	"LT": compact(`(?:LT)?\d{5}`, prefixed("LT")),
+	"LU": compact(`(?:L)?\d{4}`, prefixed("L")),
+	"LV": compact(`(?:LV)?\d{4}`, prefixed("LV")),
+	"MA": compact(`\d{5}`, nil),
+	"MX": compact(`\d{5}`, nil),
+	"MY": compact(`\d{5}`, nil),
+	"NL": compact(`[1-9]\d{3}[A-Z]{2}`, at(4, " ")),
+	"NO": compact(`\d{4}`, nil),
+	"NZ": compact(`\d{4}`, nil),
+	"PH": compact(`\d{4}`, nil),
+	"PK": compact(`\d{5}`, nil),
+	"PL": compact(`\d{5}`, at(2, "-")),
+	"PR": compact(`00[679]\d{2}(?:\d{4})?`, nil),
+	"PT": compact(`\d{7}`, at(4, "-")),
+	"RO": compact(`\d{6}`, nil),
+	"RS": compact(`\d{5}`, nil),
+	"RU": compact(`\d{6}`, nil),
+	"SA": compact(`\d{5}(?:\d{4})?`, nil),
+	"SE": compact(`\d{5}`, at(3, " ")),
+	"SG": compact(`\d{6}`, nil),
+	"SI": compact(`(?:SI)?\d{4}`, nil),
+	"SK": compact(`\d{5}`, at(3, " ")),
+	"TH": compact(`\d{5}`, nil),
+	"TN": compact(`\d{4}`, nil),
+	"TR": compact(`\d{5}`, nil),
+	"TW": compact(`\d{3}(?:\d{2,3})?`, nil),
+	"UA": compact(`\d{5}`, nil),
+	"US": compact(`\d{5}(?:\d{4})?`, nil),
+	"VN": compact(`\d{6}`, nil),
+	"ZA": compact(`\d{4}`, nil),
+}
+
+// noPostcodes are the countries without a postcode system
+var noPostcodes = map[string]bool{
+	"AE": true, "AG": true, "AO": true, "AW": true, "BF": true, "BI": true, "BJ": true, "BS": true, "BW": true,
+	"BZ": true, "CD": true, "CF": true, "CG": true, "CI": true, "CK": true, "CM": true, "DJ": true, "DM": true,
+	"ER": true, "FJ": true, "GD": true, "GH": true, "GM": true, "GQ": true, "GY": true, "HK": true, "KI": true,
+	"KM": true, "KN": true, "KP": true, "LC": true, "ML": true, "MO": true, "MR": true, "MW": true, "NR": true,
+	"NU": true, "QA": true, "RW": true, "SB": true, "SC": true, "SL": true, "SR": true, "ST": true, "SY": true,
+	"TF": true, "TK": true, "TL": true, "TO": true, "TV": true, "UG": true, "VU": true, "YE": true, "ZW": true,
+}
+
+// alpha2 returns the ISO 3166-1 alpha-2 code of the country of countryCode, which may be any ISO 3166-1 code
+func alpha2(countryCode string) string {
+	if c, ok := geo.LookupCountry(countryCode); ok {
+		return c.Alpha2
+	}
+	return strings.ToUpper(countryCode)
+}
+
+// compacted returns postcode in upper case without spaces and hyphens
+func compacted(postcode string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(postcode))
+}
+
+// Supported tells whether the postcodes of the country of countryCode can be validated,
+// being in a known format or the country having no postcodes
+func Supported(countryCode string) bool {
+	code := alpha2(countryCode)
+	_, ok := formats[code]
+	return ok || noPostcodes[code]
+}
+
+// Valid tells whether postcode is in the format of the postcodes of the country of countryCode,
+// or is empty for a country without postcodes. Any postcode is valid for a country which is not Supported
+func Valid(countryCode, postcode string) bool {
+	_, err := Normalize(countryCode, postcode)
+	return err == nil
+}
+
+// Normalize returns postcode as it is written in the country of countryCode, such as "SW1A 2AA" for "sw1a2aa",
+// or an error if it is not Valid
+func Normalize(countryCode, postcode string) (string, error) {
+	code := alpha2(countryCode)
+	if noPostcodes[code] {
+		if strings.TrimSpace(postcode) != "" {
+			return "", fmt.Errorf("country %s has no postcodes", code)
+		}
+		return "", nil
+	}
+	f, ok := formats[code]
+	if !ok {
+		return strings.Join(strings.Fields(strings.ToUpper(postcode)), " "), nil
+	}
+	c := compacted(postcode)
+	if !f.pattern.MatchString(c) {
+		return "", fmt.Errorf("invalid postcode %q for country %s", postcode, code)
+	}
+	if f.layout != nil {
+		return f.layout(c), nil
+	}
+	if code == "US" && len(c) == 9 {
+		return at(5, "-")(c), nil
+	}
+	return c, nil
+}
@@ -0,0 +1,60 @@
+package postcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	for _, c := range []struct {
+		country, postcode string
+		valid             bool
+	}{
+		{"AU", "3000", true},
+		{"AU", "300", false},
+		{"US", "90210", true},
+		{"USA", "90210-1234", true},
+		{"US", "9021", false},
+		{"GB", "SW1A 2AA", true},
+		{"GB", "EC1A1BB", true},
+		{"GB", "QQ1 1AA", false},
+		{"CA", "K1A 0B1", true},
+		{"CA", "D1A 0B1", false},
+		{"NL", "1012 JS", true},
+		{"NL", "0123 AB", false},
+		{"FR", "75008", true},
+		{"FR", "99000", false},
+		{"IE", "D02 X285", true},
+		{"JP", "100-0001", true},
+		{"HK", "", true},
+		{"HK", "999077", false},
+		{"ZZ", "anything", true},
+	} {
+		assert.Equal(t, c.valid, Valid(c.country, c.postcode), "%s %s", c.country, c.postcode)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for country, c := range map[string][2]string{
+		"GB": {"sw1a2aa", "SW1A 2AA"},
+		"CA": {"k1a0b1", "K1A 0B1"},
+		"NL": {"1012js", "1012 JS"},
+		"SE": {"11455", "114 55"},
+		"PL": {"00 950", "00-950"},
+		"PT": {"1100148", "1100-148"},
+		"BR": {"01310200", "01310-200"},
+		"JP": {"1000001", "100-0001"},
+		"US": {"902101234", "90210-1234"},
+		"LV": {"1050", "LV-1050"},
+		"DE": {"10117", "10117"},
+	} {
+		normalized, err := Normalize(country, c[0])
+		assert.NoError(t, err, country)
+		assert.Equal(t, c[1], normalized, country)
+	}
+	_, err := Normalize("DE", "1011")
+	assert.Error(t, err)
+	assert.True(t, Supported("deu"))
+	assert.False(t, Supported("ZZ"))
+}
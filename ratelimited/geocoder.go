@@ -0,0 +1,85 @@
+// Package ratelimited is a geocoder spacing out the requests to another geocoder, to stay within the rate limit
+// of its service whichever goroutines the requests come from
+package ratelimited
+
+import (
+	"sync"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// Limiter spaces out the calls to Wait by at least an interval
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewLimiter returns a limiter letting through perSecond calls a second, or any number if perSecond is not positive
+func NewLimiter(perSecond float64) *Limiter {
+	l := &Limiter{}
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return l
+}
+
+// Wait blocks until the next call is let through
+func (l *Limiter) Wait() {
+	if l.interval == 0 {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+	time.Sleep(at.Sub(now))
+}
+
+type rateLimitedGeocoder struct {
+	next    geo.Geocoder
+	limiter *Limiter
+}
+
+// Geocoder makes at most perSecond requests a second to next
+func Geocoder(next geo.Geocoder, perSecond float64) geo.Geocoder {
+	return WithLimiter(next, NewLimiter(perSecond))
+}
+
+// WithLimiter makes the requests to next when limiter lets them through,
+// which can be shared with other geocoders of the same service
+func WithLimiter(next geo.Geocoder, limiter *Limiter) geo.Geocoder {
+	return rateLimitedGeocoder{next: next, limiter: limiter}
+}
+
+// Geocode returns location for address
+func (r rateLimitedGeocoder) Geocode(address string) (*geo.Location, error) {
+	r.limiter.Wait()
+	return r.next.Geocode(address)
+}
+
+// GeocodeResult returns location for address, with what next tells about it
+func (r rateLimitedGeocoder) GeocodeResult(address string) (*geo.Result, error) {
+	r.limiter.Wait()
+	return geo.GeocodeResult(r.next, address)
+}
+
+// ReverseGeocode returns address for location
+func (r rateLimitedGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	r.limiter.Wait()
+	return r.next.ReverseGeocode(lat, lng)
+}
+
+// Capabilities returns those of next, with the rate limit of the limiter if any
+func (r rateLimitedGeocoder) Capabilities() geo.Capabilities {
+	c := geo.CapabilitiesOf(r.next)
+	if r.limiter.interval > 0 {
+		c.RateLimit = float64(time.Second) / float64(r.limiter.interval)
+	}
+	return c
+}
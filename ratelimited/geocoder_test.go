@@ -0,0 +1,59 @@
+package ratelimited_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/codingsince1985/geo-golang/ratelimited"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addressFixture  = geo.Address{FormattedAddress: "64 Elizabeth Street, Melbourne, Victoria 3000, Australia"}
+	locationFixture = geo.Location{Lat: -37.814107, Lng: 144.96328}
+	next            = data.Geocoder(
+		data.AddressToLocation{addressFixture: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture},
+	)
+)
+
+func TestGeocoder(t *testing.T) {
+	geocoder := ratelimited.Geocoder(next, 100)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			location, err := geocoder.Geocode(addressFixture.FormattedAddress)
+			assert.NoError(t, err)
+			assert.Equal(t, locationFixture, *location)
+		}()
+	}
+	wg.Wait()
+	// the first request goes through at once, and each of the others 10ms after the previous one
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	address, err := geocoder.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, addressFixture, *address)
+}
+
+func TestUnlimited(t *testing.T) {
+	geocoder := ratelimited.Geocoder(next, 0)
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		_, err := geocoder.Geocode(addressFixture.FormattedAddress)
+		assert.NoError(t, err)
+	}
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+}
+
+func TestCapabilities(t *testing.T) {
+	assert.Equal(t, geo.Capabilities{Geocode: true, Reverse: true, RateLimit: 4}, geo.CapabilitiesOf(ratelimited.Geocoder(next, 4)))
+	assert.Equal(t, geo.Capabilities{Geocode: true, Reverse: true}, geo.CapabilitiesOf(ratelimited.Geocoder(next, 0)))
+}
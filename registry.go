@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrMissingCredentials occurs when a provider needing credentials is built without them
+var ErrMissingCredentials = errors.New("credentials are missing")
+
+// Options are the settings of a provider built by name, those it has no use for being ignored
+type Options struct {
+	// Key is the API key or token of the service
+	Key string
+	// ID is the application ID of services needing one along with Key, such as HERE Geocoder 6.2
+	ID string
+	// BaseURL replaces the URL of the service, to use a mirror or a self-hosted instance
+	BaseURL string
+	// Language is the preferred language of addresses, such as "fr" or "pt-BR"
+	Language string
+	// Region is the ISO 3166-1 alpha-2 code of the country results are biased to or restricted to, as the service does
+	Region string
+	// Client sends the requests, http.DefaultClient if nil
+	Client *http.Client
+	// Timeout is how long a request can take, DefaultTimeout if zero
+	Timeout time.Duration
+	// Raw keeps the JSON responses of the service in the sources of results, see Source.Decode
+	Raw bool
+	// Extra are the settings specific to a provider, such as zoom for locationiq
+	Extra map[string]string
+}
+
+// BaseURLs returns BaseURL as the optional argument of the constructors of providers
+func (o Options) BaseURLs() []string {
+	if o.BaseURL == "" {
+		return nil
+	}
+	return []string{o.BaseURL}
+}
+
+// Apply sets the client and timeout of g, whether it keeps raw responses, and its params named language and region to Language and Region,
+// leaving out those the service has no param for, whose name is ""
+func (o Options) Apply(g HTTPGeocoder, language, region string) HTTPGeocoder {
+	g.Client = o.Client
+	g.Timeout = o.Timeout
+	g.Raw = o.Raw
+	params := url.Values{}
+	if language != "" && o.Language != "" {
+		params.Set(language, o.Language)
+	}
+	if region != "" && o.Region != "" {
+		params.Set(region, strings.ToLower(o.Region))
+	}
+	if len(params) > 0 {
+		g.Params = params
+	}
+	return g
+}
+
+// Factory builds the geocoder of a provider with options
+type Factory func(Options) (Geocoder, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes the provider built by factory available by name, replacing any provider registered
+// with that name. Provider packages register themselves when imported, so that
+//
+//	import _ "github.com/codingsince1985/geo-golang/google"
+//
+// is enough for New("google", ...) to work
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = factory
+}
+
+// Providers returns the names of the providers registered, in order
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the geocoder of the provider registered by name, built with options
+func New(name string, options Options) (Geocoder, error) {
+	registryMu.RLock()
+	factory, ok := registry[strings.ToLower(strings.TrimSpace(name))]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q, expected one of %s", name, strings.Join(Providers(), ", "))
+	}
+	return factory(options)
+}
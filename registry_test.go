@@ -0,0 +1,116 @@
+package geo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+type testURL string
+
+func (u testURL) GeocodeURL(address string) string { return string(u) + "?q=" + address }
+
+func (u testURL) ReverseGeocodeURL(l geo.Location) string {
+	return string(u) + fmt.Sprintf("?lat=%f&lon=%f", l.Lat, l.Lng)
+}
+
+type testResponse struct{ Lat, Lng float64 }
+
+func (r *testResponse) Location() (*geo.Location, error) {
+	return &geo.Location{Lat: r.Lat, Lng: r.Lng}, nil
+}
+
+func (r *testResponse) Address() (*geo.Address, error) { return nil, nil }
+
+func TestRegistry(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"Lat": -37.814107, "Lng": 144.96328}`))
+	}))
+	defer ts.Close()
+
+	geo.Register("Test", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(geo.HTTPGeocoder{
+			EndpointBuilder:       testURL(o.BaseURL),
+			ResponseParserFactory: func() geo.ResponseParser { return &testResponse{} },
+		}, "lang", "country"), nil
+	})
+	assert.Contains(t, geo.Providers(), "test")
+
+	_, err := geo.New("test", geo.Options{})
+	assert.Equal(t, geo.ErrMissingCredentials, err)
+	_, err = geo.New("nowhere", geo.Options{})
+	assert.Error(t, err)
+
+	g, err := geo.New("test", geo.Options{Key: "key", BaseURL: ts.URL, Language: "fr", Region: "FR", Client: ts.Client()})
+	assert.NoError(t, err)
+	location, err := g.Geocode("Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.814107, Lng: 144.96328}, *location)
+	assert.Equal(t, url.Values{"q": {"Paris"}, "lang": {"fr"}, "country": {"fr"}}, query)
+}
+
+func TestOptionsTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"Lat": -37.814107, "Lng": 144.96328}`))
+	}))
+	defer ts.Close()
+
+	g := geo.Options{Timeout: 10 * time.Millisecond}.Apply(geo.HTTPGeocoder{
+		EndpointBuilder:       testURL(ts.URL),
+		ResponseParserFactory: func() geo.ResponseParser { return &testResponse{} },
+	}, "", "")
+	_, err := g.Geocode("Paris")
+	assert.Equal(t, geo.ErrTimeout, err)
+
+	// beyond DefaultTimeout too
+	g = geo.Options{Timeout: 2 * geo.DefaultTimeout}.Apply(g, "", "")
+	assert.Equal(t, 2*geo.DefaultTimeout, g.Timeout)
+	location, err := g.Geocode("Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Location{Lat: -37.814107, Lng: 144.96328}, *location)
+}
+
+func TestConcurrentGeocoders(t *testing.T) {
+	var mu sync.Mutex
+	languages := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		languages[r.URL.Path] = r.URL.Query().Get("lang")
+		mu.Unlock()
+		w.Write([]byte(`{"Lat": -37.814107, "Lng": 144.96328}`))
+	}))
+	defer ts.Close()
+
+	// each geocoder keeps its own settings, whatever the others use at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g := geo.Options{Language: fmt.Sprint("lang", i)}.Apply(geo.HTTPGeocoder{
+				EndpointBuilder:       testURL(fmt.Sprintf("%s/%d", ts.URL, i)),
+				ResponseParserFactory: func() geo.ResponseParser { return &testResponse{} },
+			}, "lang", "")
+			_, err := g.Geocode("Paris")
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, fmt.Sprint("lang", i), languages[fmt.Sprintf("/%d", i)])
+	}
+}
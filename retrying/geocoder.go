@@ -0,0 +1,110 @@
+// Package retrying is a geocoder trying the requests to another geocoder again when they fail,
+// waiting longer after each failure, and giving up on those taking too long
+package retrying
+
+import (
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+)
+
+// Policy tells how often and how long requests are tried
+type Policy struct {
+	// Attempts is the number of tries of a request, 1 if zero
+	Attempts int
+	// Backoff is the wait after the first failure, doubled after each of the next ones
+	Backoff time.Duration
+	// MaxBackoff caps the wait between two tries, if positive
+	MaxBackoff time.Duration
+	// Timeout is how long a try can take before failing with geo.ErrTimeout, no limit but that of next if zero
+	Timeout time.Duration
+}
+
+type retryingGeocoder struct {
+	next   geo.Geocoder
+	policy Policy
+}
+
+// Geocoder tries the requests to next as policy tells, until one succeeds, even without a result
+func Geocoder(next geo.Geocoder, policy Policy) geo.Geocoder {
+	return retryingGeocoder{next: next, policy: policy}
+}
+
+// try calls f as the policy tells until it succeeds, returning the error of the last try otherwise
+func (r retryingGeocoder) try(f func() (interface{}, error)) (interface{}, error) {
+	attempts := r.policy.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	wait := r.policy.Backoff
+	var (
+		v   interface{}
+		err error
+	)
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(wait)
+			if wait *= 2; r.policy.MaxBackoff > 0 && wait > r.policy.MaxBackoff {
+				wait = r.policy.MaxBackoff
+			}
+		}
+		if v, err = r.attempt(f); err == nil {
+			return v, nil
+		}
+	}
+	return nil, err
+}
+
+// attempt calls f, giving up after the timeout of the policy
+func (r retryingGeocoder) attempt(f func() (interface{}, error)) (interface{}, error) {
+	if r.policy.Timeout <= 0 {
+		return f()
+	}
+	type result struct {
+		v   interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := f()
+		done <- result{v: v, err: err}
+	}()
+	timer := time.NewTimer(r.policy.Timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res.v, res.err
+	case <-timer.C:
+		return nil, geo.ErrTimeout
+	}
+}
+
+// Geocode returns location for address
+func (r retryingGeocoder) Geocode(address string) (*geo.Location, error) {
+	v, err := r.try(func() (interface{}, error) { return r.next.Geocode(address) })
+	if v == nil {
+		return nil, err
+	}
+	return v.(*geo.Location), err
+}
+
+// GeocodeResult returns location for address, with what next tells about it
+func (r retryingGeocoder) GeocodeResult(address string) (*geo.Result, error) {
+	v, err := r.try(func() (interface{}, error) { return geo.GeocodeResult(r.next, address) })
+	if v == nil {
+		return nil, err
+	}
+	return v.(*geo.Result), err
+}
+
+// ReverseGeocode returns address for location
+func (r retryingGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	v, err := r.try(func() (interface{}, error) { return r.next.ReverseGeocode(lat, lng) })
+	if v == nil {
+		return nil, err
+	}
+	return v.(*geo.Address), err
+}
+
+// Capabilities returns those of next
+func (r retryingGeocoder) Capabilities() geo.Capabilities { return geo.CapabilitiesOf(r.next) }
@@ -0,0 +1,76 @@
+package retrying_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/retrying"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	locationFixture = geo.Location{Lat: -37.814107, Lng: 144.96328}
+	addressFixture  = geo.Address{FormattedAddress: "64 Elizabeth Street, Melbourne, Victoria 3000, Australia"}
+	errFlaky        = errors.New("flaky")
+)
+
+// flaky fails the first failures requests, and takes delay to answer each
+type flaky struct {
+	calls    *int
+	failures int
+	delay    time.Duration
+}
+
+func (f flaky) Geocode(address string) (*geo.Location, error) {
+	time.Sleep(f.delay)
+	if *f.calls++; *f.calls <= f.failures {
+		return nil, errFlaky
+	}
+	return &locationFixture, nil
+}
+
+func (f flaky) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	if *f.calls++; *f.calls <= f.failures {
+		return nil, errFlaky
+	}
+	return &addressFixture, nil
+}
+
+func TestGeocoder(t *testing.T) {
+	calls := 0
+	geocoder := retrying.Geocoder(flaky{calls: &calls, failures: 2}, retrying.Policy{Attempts: 3, Backoff: 10 * time.Millisecond})
+
+	start := time.Now()
+	location, err := geocoder.Geocode(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, locationFixture, *location)
+	assert.Equal(t, 3, calls)
+	// waits 10ms after the first failure and 20ms after the second one
+	assert.True(t, time.Since(start) >= 30*time.Millisecond)
+
+	calls = 0
+	address, err := geocoder.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, addressFixture, *address)
+}
+
+func TestGiveUp(t *testing.T) {
+	calls := 0
+	geocoder := retrying.Geocoder(flaky{calls: &calls, failures: 5}, retrying.Policy{Attempts: 3, Backoff: time.Millisecond})
+
+	location, err := geocoder.Geocode(addressFixture.FormattedAddress)
+	assert.Equal(t, errFlaky, err)
+	assert.Nil(t, location)
+	assert.Equal(t, 3, calls)
+}
+
+func TestTimeout(t *testing.T) {
+	calls := 0
+	geocoder := retrying.Geocoder(flaky{calls: &calls, delay: 100 * time.Millisecond}, retrying.Policy{Timeout: 10 * time.Millisecond})
+
+	location, err := geocoder.Geocode(addressFixture.FormattedAddress)
+	assert.Equal(t, geo.ErrTimeout, err)
+	assert.Nil(t, location)
+}
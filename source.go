@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Terms are the terms on which results of a provider can be used
+type Terms struct {
+	// Attribution is the notice to display with results, if the provider requires one
+	Attribution string
+	// Licence is the licence of the data, or the terms of the service using it
+	Licence string
+	// NoStore tells whether results must not be cached or stored
+	NoStore bool
+	// MaxAge is how long results can be cached or stored, without limit if zero
+	MaxAge time.Duration
+}
+
+// Source is the provider of a result, the terms of its use, and how it was found
+type Source struct {
+	// Provider is the name of the provider, as registered
+	Provider string
+	Terms
+	// URL is the URL of the request, with its keys and tokens redacted
+	URL string
+	// Status is the HTTP status of the response
+	Status int
+	// Latency is how long the request took until the whole response was read
+	Latency time.Duration
+	// Cached tells whether the result was found in a cache rather than asked to the provider
+	Cached bool
+	// Position is the position from 1 of the provider in the chain of geocoders which found the result, 0 if not chained
+	Position int
+	// Raw is the JSON response of the service, kept only if asked with HTTPGeocoder.Raw or Options.Raw
+	Raw json.RawMessage
+}
+
+// ErrNoRaw occurs when decoding the response of a source which did not keep it
+var ErrNoRaw = errors.New("raw response not kept")
+
+// Decode decodes the raw response of the service into v, such as a struct with the fields of the
+// provider which results do not model, or a map[string]interface{}
+func (s *Source) Decode(v interface{}) error {
+	if s == nil || len(s.Raw) == 0 {
+		return ErrNoRaw
+	}
+	return json.Unmarshal(s.Raw, v)
+}
+
+// secrets are the names of the params of the services holding keys or tokens
+var secrets = map[string]bool{
+	"access_token": true,
+	"api_key":      true,
+	"apikey":       true,
+	"app_code":     true,
+	"key":          true,
+	"token":        true,
+}
+
+// redact returns u with the values of its params holding keys or tokens replaced
+func redact(u *url.URL) string {
+	params := strings.Split(u.RawQuery, "&")
+	for i, p := range params {
+		if j := strings.Index(p, "="); j > 0 && secrets[strings.ToLower(p[:j])] {
+			params[i] = p[:j+1] + "REDACTED"
+		}
+	}
+	redacted := *u
+	redacted.RawQuery = strings.Join(params, "&")
+	return redacted.String()
+}
+
+// LicensingParser is implemented by ResponseParsers of services which return the attribution
+// and licence of their data, replacing those they report in their Capabilities
+type LicensingParser interface {
+	Licensing() (attribution, licence string)
+}
+
+// GeocodeResult returns what g tells about the location of address, with GeocodeResult if g is a ResultGeocoder,
+// and the provider reported by its capabilities as source if it tells none
+func GeocodeResult(g Geocoder, address string) (*Result, error) {
+	if r, ok := g.(ResultGeocoder); ok {
+		res, err := r.GeocodeResult(address)
+		if res != nil && res.Source == nil {
+			res.Source = sourceOf(g)
+		}
+		return res, err
+	}
+	l, err := g.Geocode(address)
+	if l == nil {
+		return nil, err
+	}
+	return &Result{Location: *l, Source: sourceOf(g)}, err
+}
+
+// sourceOf returns the source of the results of g as reported by its capabilities, or nil if they do not tell
+func sourceOf(g Geocoder) *Source {
+	c := CapabilitiesOf(g)
+	if c.Provider == "" {
+		return nil
+	}
+	return &Source{Provider: c.Provider, Terms: c.Terms}
+}
@@ -0,0 +1,42 @@
+package geo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+type keyURL struct{ base, key string }
+
+func (u keyURL) GeocodeURL(address string) string { return u.base + "?key=" + u.key + "&q=" + address }
+
+func (u keyURL) ReverseGeocodeURL(l geo.Location) string { return u.base + "?key=" + u.key }
+
+func TestSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Lat": -37.814107, "Lng": 144.96328}`))
+	}))
+	defer ts.Close()
+
+	g := geo.HTTPGeocoder{
+		EndpointBuilder:       keyURL{base: ts.URL + "/search", key: "secret"},
+		ResponseParserFactory: func() geo.ResponseParser { return &testResponse{} },
+	}
+	res, err := geo.GeocodeResult(g, "Melbourne")
+	assert.NoError(t, err)
+	assert.Equal(t, ts.URL+"/search?key=REDACTED&q=Melbourne", res.Source.URL)
+	assert.Equal(t, http.StatusOK, res.Source.Status)
+	assert.True(t, res.Source.Latency > 0)
+	assert.Nil(t, res.Source.Raw)
+	assert.Equal(t, geo.ErrNoRaw, res.Source.Decode(&map[string]interface{}{}))
+
+	g.Raw = true
+	res, err = geo.GeocodeResult(g, "Melbourne")
+	assert.NoError(t, err)
+	var raw map[string]interface{}
+	assert.NoError(t, res.Source.Decode(&raw))
+	assert.Equal(t, map[string]interface{}{"Lat": -37.814107, "Lng": 144.96328}, raw)
+}
@@ -0,0 +1,138 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Timezones are the boundaries of IANA timezones, such as those released by timezone-boundary-builder
+type Timezones struct {
+	zones []timezoneArea
+}
+
+// timezoneArea is a polygon of a timezone, its first ring being the outline and the others its holes
+type timezoneArea struct {
+	tzid  string
+	box   BoundingBox
+	rings [][][2]float64
+}
+
+// LoadTimezones returns the timezone boundaries of the GeoJSON FeatureCollection in r,
+// with the IANA name of the timezone of each feature in its tzid property, such as combined.json of
+// https://github.com/evansiroky/timezone-boundary-builder/releases
+func LoadTimezones(r io.Reader) (*Timezones, error) {
+	var collection struct {
+		Features []struct {
+			Properties struct {
+				TZID string `json:"tzid"`
+			}
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+		}
+	}
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, err
+	}
+
+	t := &Timezones{}
+	for _, f := range collection.Features {
+		var polygons [][][][2]float64
+		switch f.Geometry.Type {
+		case "Polygon":
+			var polygon [][][2]float64
+			if err := json.Unmarshal(f.Geometry.Coordinates, &polygon); err != nil {
+				return nil, fmt.Errorf("timezone %s: %v", f.Properties.TZID, err)
+			}
+			polygons = append(polygons, polygon)
+		case "MultiPolygon":
+			if err := json.Unmarshal(f.Geometry.Coordinates, &polygons); err != nil {
+				return nil, fmt.Errorf("timezone %s: %v", f.Properties.TZID, err)
+			}
+		default:
+			return nil, fmt.Errorf("timezone %s: unsupported geometry %q", f.Properties.TZID, f.Geometry.Type)
+		}
+		for _, rings := range polygons {
+			if len(rings) > 0 && len(rings[0]) > 0 {
+				t.zones = append(t.zones, timezoneArea{tzid: f.Properties.TZID, box: ringBox(rings[0]), rings: rings})
+			}
+		}
+	}
+	return t, nil
+}
+
+// ringBox returns the extent of ring, whose points are longitude then latitude
+func ringBox(ring [][2]float64) BoundingBox {
+	b := BoundingBox{South: ring[0][1], West: ring[0][0], North: ring[0][1], East: ring[0][0]}
+	for _, p := range ring[1:] {
+		b.West, b.East = math.Min(b.West, p[0]), math.Max(b.East, p[0])
+		b.South, b.North = math.Min(b.South, p[1]), math.Max(b.North, p[1])
+	}
+	return b
+}
+
+// inRing tells whether l is inside ring, by the number of its edges crossed by a ray going east
+func inRing(l Location, ring [][2]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > l.Lat) != (b[1] > l.Lat) && l.Lng < (b[0]-a[0])*(l.Lat-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func (z timezoneArea) contains(l Location) bool {
+	if !z.box.Contains(l) || !inRing(l, z.rings[0]) {
+		return false
+	}
+	for _, hole := range z.rings[1:] {
+		if inRing(l, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+// Lookup returns the IANA name of the timezone whose boundaries contain l, or "" if none does
+func (t *Timezones) Lookup(l Location) string {
+	l = l.Normalize()
+	for _, z := range t.zones {
+		if z.contains(l) {
+			return z.tzid
+		}
+	}
+	return ""
+}
+
+// NauticalTimezone returns the IANA name of the nautical timezone of l, from Etc/GMT+12 to Etc/GMT-12
+// by 15 degrees of longitude, whose sign is the opposite of its offset from UTC
+func NauticalTimezone(l Location) string {
+	lng := l.Lng
+	if lng < -180 || lng > 180 {
+		lng = WrapLongitude(lng)
+	}
+	offset := int(math.Round(lng / 15))
+	switch {
+	case offset > 0:
+		return fmt.Sprintf("Etc/GMT-%d", offset)
+	case offset < 0:
+		return fmt.Sprintf("Etc/GMT+%d", -offset)
+	}
+	return "Etc/GMT"
+}
+
+// TimezoneIn returns the IANA name of the timezone of l in timezones,
+// or of its nautical timezone outside them or if timezones is nil
+func (l Location) TimezoneIn(timezones *Timezones) string {
+	if timezones != nil {
+		if tzid := timezones.Lookup(l); tzid != "" {
+			return tzid
+		}
+	}
+	return NauticalTimezone(l)
+}
@@ -0,0 +1,53 @@
+// Package timezone is a geocoder adding the IANA timezone to the results of another geocoder,
+// keeping the timezone its service supplies if any
+package timezone
+
+import (
+	"github.com/codingsince1985/geo-golang"
+)
+
+type timezoneGeocoder struct {
+	next      geo.Geocoder
+	timezones *geo.Timezones
+}
+
+// Geocoder adds timezones to the results of next, looking them up in timezones,
+// or using nautical timezones outside them or if nil, for locations whose service does not supply one
+func Geocoder(next geo.Geocoder, timezones *geo.Timezones) geo.ResultGeocoder {
+	return timezoneGeocoder{next: next, timezones: timezones}
+}
+
+// lookup returns the timezone of l
+func (g timezoneGeocoder) lookup(l geo.Location) string { return l.TimezoneIn(g.timezones) }
+
+// Geocode returns location for address
+func (g timezoneGeocoder) Geocode(address string) (*geo.Location, error) {
+	return g.next.Geocode(address)
+}
+
+// GeocodeResult returns location for address with its timezone
+func (g timezoneGeocoder) GeocodeResult(address string) (*geo.Result, error) {
+	res, err := geo.GeocodeResult(g.next, address)
+	if res == nil {
+		return nil, err
+	}
+	if res.Timezone == "" {
+		res.Timezone = g.lookup(res.Location)
+	}
+	return res, err
+}
+
+// ReverseGeocode returns address for location with its timezone
+func (g timezoneGeocoder) ReverseGeocode(lat, lng float64) (*geo.Address, error) {
+	addr, err := g.next.ReverseGeocode(lat, lng)
+	if addr == nil {
+		return nil, err
+	}
+	if addr.Timezone == "" {
+		addr.Timezone = g.lookup(geo.Location{Lat: lat, Lng: lng})
+	}
+	return addr, err
+}
+
+// Capabilities returns those of next
+func (g timezoneGeocoder) Capabilities() geo.Capabilities { return geo.CapabilitiesOf(g.next) }
@@ -0,0 +1,60 @@
+package timezone_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/codingsince1985/geo-golang/data"
+	"github.com/codingsince1985/geo-golang/timezone"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addressFixture = geo.Address{FormattedAddress: "64 Elizabeth Street, Melbourne, Victoria 3000, Australia"}
+	sydneyFixture  = geo.Address{FormattedAddress: "Sydney NSW, Australia", Timezone: "Australia/Sydney"}
+
+	locationFixture = geo.Location{Lat: -37.814107, Lng: 144.96328}
+	sydneyLocation  = geo.Location{Lat: -33.8688, Lng: 151.2093}
+
+	next = data.Geocoder(
+		data.AddressToLocation{addressFixture: locationFixture},
+		data.LocationToAddress{locationFixture: addressFixture, sydneyLocation: sydneyFixture},
+	)
+)
+
+const victoria = `{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"tzid": "Australia/Melbourne"},
+	"geometry": {"type": "Polygon", "coordinates": [[[141, -34], [150, -37.5], [141, -39], [141, -34]]]}}]}`
+
+func TestGeocodeResult(t *testing.T) {
+	timezones, err := geo.LoadTimezones(strings.NewReader(victoria))
+	assert.NoError(t, err)
+	geocoder := timezone.Geocoder(next, timezones)
+
+	result, err := geocoder.GeocodeResult(addressFixture.FormattedAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, &geo.Result{Location: locationFixture, Timezone: "Australia/Melbourne"}, result)
+
+	result, err = geocoder.GeocodeResult("nowhere")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestReverseGeocode(t *testing.T) {
+	timezones, err := geo.LoadTimezones(strings.NewReader(victoria))
+	assert.NoError(t, err)
+	geocoder := timezone.Geocoder(next, timezones)
+
+	addr, err := geocoder.ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, "Australia/Melbourne", addr.Timezone)
+
+	// the timezone supplied is kept
+	addr, err = geocoder.ReverseGeocode(sydneyLocation.Lat, sydneyLocation.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, "Australia/Sydney", addr.Timezone)
+
+	addr, err = timezone.Geocoder(next, nil).ReverseGeocode(locationFixture.Lat, locationFixture.Lng)
+	assert.NoError(t, err)
+	assert.Equal(t, "Etc/GMT-10", addr.Timezone)
+}
@@ -0,0 +1,54 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// timezoneBoundaries are rough outlines of Victoria, with a hole around Melbourne given to a made up zone,
+// and of two parts of New Zealand
+const timezoneBoundaries = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Australia/Melbourne"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[141, -34], [150, -37.5], [141, -39], [141, -34]],
+		[[144.5, -37.5], [145.5, -37.5], [145.5, -38], [144.5, -38], [144.5, -37.5]]
+	]}},
+	{"type": "Feature", "properties": {"tzid": "Test/Melbourne"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[144.5, -37.5], [145.5, -37.5], [145.5, -38], [144.5, -38], [144.5, -37.5]]
+	]}},
+	{"type": "Feature", "properties": {"tzid": "Pacific/Auckland"}, "geometry": {"type": "MultiPolygon", "coordinates": [
+		[[[172, -34], [179, -37], [174, -42], [172, -34]]],
+		[[[166, -46], [174, -41], [172, -47], [166, -46]]]
+	]}}
+]}`
+
+func TestLoadTimezones(t *testing.T) {
+	timezones, err := LoadTimezones(strings.NewReader(timezoneBoundaries))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Australia/Melbourne", timezones.Lookup(Location{Lat: -36.7570, Lng: 144.2794}))
+	assert.Equal(t, "Test/Melbourne", timezones.Lookup(Location{Lat: -37.8136, Lng: 144.9631}))
+	assert.Equal(t, "Pacific/Auckland", timezones.Lookup(Location{Lat: -36.8485, Lng: 174.7633}))
+	assert.Equal(t, "Pacific/Auckland", timezones.Lookup(Location{Lat: -43.5321, Lng: 172.6362}))
+	assert.Equal(t, "", timezones.Lookup(Location{Lat: -33.8688, Lng: 151.2093}))
+
+	_, err = LoadTimezones(strings.NewReader(`{"features": [{"properties": {"tzid": "X"}, "geometry": {"type": "Point", "coordinates": [0, 0]}}]}`))
+	assert.Error(t, err)
+}
+
+func TestNauticalTimezone(t *testing.T) {
+	assert.Equal(t, "Etc/GMT", NauticalTimezone(Location{Lat: 0, Lng: -7}))
+	assert.Equal(t, "Etc/GMT-10", NauticalTimezone(Location{Lat: -37.8136, Lng: 144.9631}))
+	assert.Equal(t, "Etc/GMT+8", NauticalTimezone(Location{Lat: 37.3861, Lng: -122.0839}))
+	assert.Equal(t, "Etc/GMT-12", NauticalTimezone(Location{Lat: 0, Lng: 180}))
+	assert.Equal(t, "Etc/GMT+12", NauticalTimezone(Location{Lat: 0, Lng: -179}))
+}
+
+func TestLocationTimezone(t *testing.T) {
+	timezones, err := LoadTimezones(strings.NewReader(timezoneBoundaries))
+	assert.NoError(t, err)
+	assert.Equal(t, "Etc/GMT-12", Location{Lat: -36.8485, Lng: 174.7633}.TimezoneIn(nil))
+	assert.Equal(t, "Pacific/Auckland", Location{Lat: -36.8485, Lng: 174.7633}.TimezoneIn(timezones))
+	assert.Equal(t, "Etc/GMT-10", Location{Lat: -33.8688, Lng: 151.2093}.TimezoneIn(timezones))
+}
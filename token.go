@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is how long before its expiry a token is refreshed
+const tokenExpiryMargin = time.Minute
+
+// defaultTokenLifetime is how long a token is kept when its service does not tell when it expires
+const defaultTokenLifetime = time.Hour
+
+// TokenSource provides the token authenticating the requests of a geocoder, requesting new tokens with the client
+// of the geocoder, or with one timing out after DefaultTimeout if it is nil
+type TokenSource interface {
+	Token(client *http.Client) (string, error)
+}
+
+// TokenFetcher requests a new token with client, returning it with its expiry, or the zero time if unknown
+type TokenFetcher func(client *http.Client) (token string, expiry time.Time, err error)
+
+type refreshingToken struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	fetch  TokenFetcher
+}
+
+// RefreshingTokenSource returns a TokenSource of the tokens of fetch, each one kept until shortly before it expires
+func RefreshingTokenSource(fetch TokenFetcher) TokenSource { return &refreshingToken{fetch: fetch} }
+
+func (t *refreshingToken) Token(client *http.Client) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Add(tokenExpiryMargin).Before(t.expiry) {
+		return t.token, nil
+	}
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	token, expiry, err := t.fetch(client)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", errors.New("token error: no token returned")
+	}
+	if expiry.IsZero() {
+		expiry = time.Now().Add(defaultTokenLifetime)
+	}
+	t.token, t.expiry = token, expiry
+	return token, nil
+}
+
+// TokenExpiry returns the expiry of a token lasting seconds from now, or the zero time if seconds is not positive
+func TokenExpiry(seconds int) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(seconds) * time.Second)
+}
+
+// PostTokenForm posts form with header to the token endpoint at tokenURL with client,
+// and decodes its JSON response into obj, failing with the response if its status is not 2xx
+func PostTokenForm(client *http.Client, tokenURL string, form url.Values, header http.Header, obj interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("token error: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
@@ -0,0 +1,53 @@
+package geo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codingsince1985/geo-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenSource(tokenURL string) geo.TokenSource {
+	return geo.RefreshingTokenSource(func(client *http.Client) (string, time.Time, error) {
+		var r struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+		}
+		err := geo.PostTokenForm(client, tokenURL, url.Values{"grant_type": {"client_credentials"}}, nil, &r)
+		return r.AccessToken, geo.TokenExpiry(r.ExpiresIn), err
+	})
+}
+
+func TestRefreshingTokenSourceWithoutExpiry(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		resp.Write([]byte(`{"access_token": "token"}`))
+	}))
+	defer ts.Close()
+
+	tokens := tokenSource(ts.URL)
+	for i := 0; i < 2; i++ {
+		token, err := tokens.Token(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "token", token)
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestPostTokenFormStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.WriteHeader(http.StatusForbidden)
+		resp.Write([]byte(`{"access_token": "token"}`))
+	}))
+	defer ts.Close()
+
+	_, err := tokenSource(ts.URL).Token(nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "403 Forbidden")
+	}
+}
@@ -20,6 +20,12 @@ type (
 				Lat float64
 				Lon float64
 			}
+			Viewport *struct {
+				TopLeftPoint, BtmRightPoint struct {
+					Lat float64
+					Lon float64
+				}
+			}
 		}
 
 		// Reverse Geocoding response
@@ -45,6 +51,15 @@ type (
 	}
 )
 
+func init() {
+	geo.Register("tomtom", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "language", "countrySet"), nil
+	})
+}
+
 // Geocoder constructs TomTom geocoder
 func Geocoder(key string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -60,6 +75,26 @@ func getUrl(key string, baseURLs ...string) string {
 	return "https://api.tomtom.com/search/2/*?key=" + key
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "tomtom",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Structured:         true,
+	Languages:          true,
+	Region:             true,
+	BoundingBoxBias:    true,
+	RateLimit:          5,
+	Terms: geo.Terms{
+		Attribution: "© TomTom",
+		Licence:     "TomTom Terms and Conditions",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	params := fmt.Sprintf("geocode/%s.json", address)
 	return strings.Replace(string(b), "*", params, 1)
@@ -82,6 +117,14 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	return nil, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.Results) == 0 || r.Results[0].Viewport == nil {
+		return nil
+	}
+	v := r.Results[0].Viewport
+	return &geo.BoundingBox{South: v.BtmRightPoint.Lat, West: v.TopLeftPoint.Lon, North: v.TopLeftPoint.Lat, East: v.BtmRightPoint.Lon}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if len(r.Addresses) > 0 {
 		a := r.Addresses[0].Address
@@ -0,0 +1,198 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// UTM scale factor on the central meridian, and false easting and northing, in meters
+const (
+	utmScale         = 0.9996
+	utmFalseEasting  = 500000.0
+	utmFalseNorthing = 10000000.0
+)
+
+// latitudeBands are the letters of the 8 degree bands of UTM and MGRS from 80°S to 84°N, X being 12 degrees
+const latitudeBands = "CDEFGHJKLMNPQRSTUVWXX"
+
+// Krüger series coefficients of the transverse Mercator projection of WGS84, to 6th order in n
+var (
+	utmN = Flattening / (2 - Flattening)
+	utmA = EquatorialRadius / (1 + utmN) * (1 + math.Pow(utmN, 2)/4 + math.Pow(utmN, 4)/64 + math.Pow(utmN, 6)/256)
+	utmE = math.Sqrt(Flattening * (2 - Flattening))
+
+	utmAlpha = krugerSeries(
+		[]float64{1.0 / 2, -2.0 / 3, 5.0 / 16, 41.0 / 180, -127.0 / 288, 7891.0 / 37800},
+		[]float64{13.0 / 48, -3.0 / 5, 557.0 / 1440, 281.0 / 630, -1983433.0 / 1935360},
+		[]float64{61.0 / 240, -103.0 / 140, 15061.0 / 26880, 167603.0 / 181440},
+		[]float64{49561.0 / 161280, -179.0 / 168, 6601661.0 / 7257600},
+		[]float64{34729.0 / 80640, -3418889.0 / 1995840},
+		[]float64{212378941.0 / 319334400},
+	)
+	utmBeta = krugerSeries(
+		[]float64{1.0 / 2, -2.0 / 3, 37.0 / 96, -1.0 / 360, -81.0 / 512, 96199.0 / 604800},
+		[]float64{1.0 / 48, 1.0 / 15, -437.0 / 1440, 46.0 / 105, -1118711.0 / 3870720},
+		[]float64{17.0 / 480, -37.0 / 840, -209.0 / 4480, 5569.0 / 90720},
+		[]float64{4397.0 / 161280, -11.0 / 504, -830251.0 / 7257600},
+		[]float64{4583.0 / 161280, -108847.0 / 3991680},
+		[]float64{20648693.0 / 638668800},
+	)
+)
+
+// krugerSeries evaluates the series of each coefficient, the jth starting at n^j
+func krugerSeries(series ...[]float64) []float64 {
+	coefficients := make([]float64, len(series))
+	for j, s := range series {
+		for i, c := range s {
+			coefficients[j] += c * math.Pow(utmN, float64(j+i+1))
+		}
+	}
+	return coefficients
+}
+
+var utmPattern = regexp.MustCompile(`^(\d{1,2})\s*([C-HJ-NP-X])\s+(\d+(?:\.\d+)?)\s*(?:M?E)?\s+(\d+(?:\.\d+)?)\s*(?:M?N)?$`)
+
+// UTM is a location in the Universal Transverse Mercator system, between 80°S and 84°N.
+// Band is the letter of the latitude band, which also tells the hemisphere, N and above being north
+type UTM struct {
+	Zone              int
+	Band              byte
+	Easting, Northing float64
+}
+
+// LocationToUTM returns l in UTM, in the zone of its longitude with the exceptions for Norway and Svalbard
+func LocationToUTM(l Location) (UTM, error) {
+	if err := l.Validate(); err != nil {
+		return UTM{}, err
+	}
+	if l.Lat < -80 || l.Lat > 84 {
+		return UTM{}, fmt.Errorf("latitude %v outside UTM limits", l.Lat)
+	}
+	l.Lng = WrapLongitude(l.Lng)
+
+	zone := int(math.Floor((l.Lng+180)/6)) + 1
+	band := latitudeBands[int(math.Floor(l.Lat/8+10))]
+	switch {
+	case zone == 31 && band == 'V' && l.Lng >= 3:
+		zone = 32
+	case band == 'X' && zone == 32:
+		zone = 31
+		if l.Lng >= 9 {
+			zone = 33
+		}
+	case band == 'X' && zone == 34:
+		zone = 33
+		if l.Lng >= 21 {
+			zone = 35
+		}
+	case band == 'X' && zone == 36:
+		zone = 35
+		if l.Lng >= 33 {
+			zone = 37
+		}
+	}
+
+	x, y := transverseMercator(l, centralMeridian(zone))
+	easting, northing := x+utmFalseEasting, y
+	if l.Lat < 0 {
+		northing += utmFalseNorthing
+	}
+	return UTM{Zone: zone, Band: band, Easting: easting, Northing: northing}, nil
+}
+
+// centralMeridian returns the longitude in degrees in the middle of zone
+func centralMeridian(zone int) float64 { return float64(zone-1)*6 - 180 + 3 }
+
+// transverseMercator projects l to meters from the intersection of the equator and the central meridian lng0
+func transverseMercator(l Location, lng0 float64) (float64, float64) {
+	phi, lambda := radians(l.Lat), radians(l.Lng-lng0)
+	cosLambda, sinLambda := math.Cos(lambda), math.Sin(lambda)
+
+	tau := math.Tan(phi)
+	sigma := math.Sinh(utmE * math.Atanh(utmE*tau/math.Sqrt(1+tau*tau)))
+	tauP := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+	xiP := math.Atan2(tauP, cosLambda)
+	etaP := math.Asinh(sinLambda / math.Sqrt(tauP*tauP+cosLambda*cosLambda))
+
+	xi, eta := xiP, etaP
+	for j, a := range utmAlpha {
+		k := 2 * float64(j+1)
+		xi += a * math.Sin(k*xiP) * math.Cosh(k*etaP)
+		eta += a * math.Cos(k*xiP) * math.Sinh(k*etaP)
+	}
+	return utmScale * utmA * eta, utmScale * utmA * xi
+}
+
+// ParseUTM returns the UTM coordinates in s, as zone and latitude band, easting and northing, such as "55H 320704 5812735"
+func ParseUTM(s string) (UTM, error) {
+	m := utmPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return UTM{}, fmt.Errorf("%q is not UTM coordinates", s)
+	}
+	zone, _ := strconv.Atoi(m[1])
+	u := UTM{Zone: zone, Band: m[2][0], Easting: ParseFloat(m[3]), Northing: ParseFloat(m[4])}
+	return u, u.Validate()
+}
+
+// Validate returns an error if u is outside its zone, band or hemisphere
+func (u UTM) Validate() error {
+	if u.Zone < 1 || u.Zone > 60 {
+		return fmt.Errorf("invalid UTM zone %d", u.Zone)
+	}
+	if !strings.ContainsRune(latitudeBands, rune(u.Band)) {
+		return fmt.Errorf("invalid UTM latitude band %q", u.Band)
+	}
+	if u.Easting < 100000 || u.Easting > 1000000 {
+		return fmt.Errorf("UTM easting %v out of range", u.Easting)
+	}
+	if u.Northing < 0 || u.Northing > utmFalseNorthing {
+		return fmt.Errorf("UTM northing %v out of range", u.Northing)
+	}
+	return nil
+}
+
+// north tells whether u is in the northern hemisphere
+func (u UTM) north() bool { return u.Band >= 'N' }
+
+// Location returns u as latitude and longitude
+func (u UTM) Location() Location {
+	x, y := u.Easting-utmFalseEasting, u.Northing
+	if !u.north() {
+		y -= utmFalseNorthing
+	}
+
+	eta, xi := x/(utmScale*utmA), y/(utmScale*utmA)
+	xiP, etaP := xi, eta
+	for j, b := range utmBeta {
+		k := 2 * float64(j+1)
+		xiP -= b * math.Sin(k*xi) * math.Cosh(k*eta)
+		etaP -= b * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	sinhEtaP, sinXiP, cosXiP := math.Sinh(etaP), math.Sin(xiP), math.Cos(xiP)
+	tauP := sinXiP / math.Sqrt(sinhEtaP*sinhEtaP+cosXiP*cosXiP)
+
+	// Newton-Raphson for the latitude whose conformal latitude is tauP
+	tau := tauP
+	for i := 0; i < 100; i++ {
+		sigma := math.Sinh(utmE * math.Atanh(utmE*tau/math.Sqrt(1+tau*tau)))
+		tauI := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+		delta := (tauP - tauI) / math.Sqrt(1+tauI*tauI) *
+			(1 + (1-utmE*utmE)*tau*tau) / ((1 - utmE*utmE) * math.Sqrt(1+tau*tau))
+		tau += delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+
+	lambda := math.Atan2(sinhEtaP, cosXiP)
+	return Location{Lat: degrees(math.Atan(tau)), Lng: WrapLongitude(centralMeridian(u.Zone) + degrees(lambda))}
+}
+
+// String returns u as zone and latitude band, easting and northing to the meter, such as "55H 320704 5812735"
+func (u UTM) String() string {
+	return fmt.Sprintf("%d%c %.0f %.0f", u.Zone, u.Band, u.Easting, u.Northing)
+}
@@ -68,6 +68,15 @@ const (
 	componentTypeCountry       = "country"
 )
 
+func init() {
+	geo.Register("yandex", func(o geo.Options) (geo.Geocoder, error) {
+		if o.Key == "" {
+			return nil, geo.ErrMissingCredentials
+		}
+		return o.Apply(Geocoder(o.Key, o.BaseURLs()...).(geo.HTTPGeocoder), "lang", ""), nil
+	})
+}
+
 // Geocoder constructs Yandex geocoder
 func Geocoder(apiKey string, baseURLs ...string) geo.Geocoder {
 	return geo.HTTPGeocoder{
@@ -83,6 +92,23 @@ func getURL(apiKey string, baseURLs ...string) string {
 	return fmt.Sprintf("https://geocode-maps.yandex.ru/1.x/?results=1&lang=en_US&format=json&apikey=%s&", apiKey)
 }
 
+// capabilities are those of the service
+var capabilities = geo.Capabilities{
+	Provider:           "yandex",
+	Geocode:            true,
+	Reverse:            true,
+	ReverseGranularity: geo.GranularityAddress,
+	Languages:          true,
+	BoundingBoxBias:    true,
+	Terms: geo.Terms{
+		Attribution: "© Yandex",
+		Licence:     "Yandex Maps API Terms of Use",
+	},
+}
+
+// Capabilities returns the capabilities of the service
+func (b baseURL) Capabilities() geo.Capabilities { return capabilities }
+
 func (b baseURL) GeocodeURL(address string) string {
 	return string(b) + "geocode=" + address
 }
@@ -110,6 +136,24 @@ func (r *geocodeResponse) Location() (*geo.Location, error) {
 	return result, nil
 }
 
+func (r *geocodeResponse) BoundingBox() *geo.BoundingBox {
+	if len(r.Response.GeoObjectCollection.FeatureMember) == 0 {
+		return nil
+	}
+	envelope := r.Response.GeoObjectCollection.FeatureMember[0].GeoObject.BoundedBy.Envelope
+	lower, upper := strings.Fields(envelope.LowerCorner), strings.Fields(envelope.UpperCorner)
+	if len(lower) != 2 || len(upper) != 2 {
+		return nil
+	}
+	// corners are in format "long lat" too
+	return &geo.BoundingBox{
+		South: geo.ParseFloat(lower[1]),
+		West:  geo.ParseFloat(lower[0]),
+		North: geo.ParseFloat(upper[1]),
+		East:  geo.ParseFloat(upper[0]),
+	}
+}
+
 func (r *geocodeResponse) Address() (*geo.Address, error) {
 	if r.Response.GeoObjectCollection.MetaDataProperty.GeocoderResponseMetaData.Found == "0" {
 		return nil, nil
@@ -24,6 +24,49 @@ func TestGeocode(t *testing.T) {
 	assert.Equal(t, geo.Location{Lat: -37.816939, Lng: 144.961515}, *location)
 }
 
+func TestGeocodeResult(t *testing.T) {
+	ts := testServer(response1)
+	defer ts.Close()
+
+	geocoder := yandex.Geocoder(token, ts.URL+"/").(geo.ResultGeocoder)
+	result, err := geocoder.GeocodeResult("60 Collins St, Melbourne VIC 3000")
+	assert.NoError(t, err)
+	assert.Equal(t, &geo.BoundingBox{South: -37.820788, West: 144.948795, North: -37.812984, East: 144.974199}, result.BoundingBox)
+	assert.Equal(t, "Collins Street", result.Address.Street)
+	assert.Equal(t, "AU", result.Address.CountryCode)
+}
+
+func TestRaw(t *testing.T) {
+	ts := testServer(response1)
+	defer ts.Close()
+
+	geocoder, err := geo.New("yandex", geo.Options{Key: "key", BaseURL: ts.URL + "/", Raw: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := geo.GeocodeResult(geocoder, "60 Collins St, Melbourne VIC 3000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Response struct {
+			GeoObjectCollection struct {
+				FeatureMember []struct {
+					GeoObject struct {
+						MetaDataProperty struct {
+							GeocoderMetaData struct {
+								Kind string `json:"kind"`
+							} `json:"GeocoderMetaData"`
+						} `json:"metaDataProperty"`
+					} `json:"GeoObject"`
+				} `json:"featureMember"`
+			} `json:"GeoObjectCollection"`
+		} `json:"response"`
+	}
+	assert.NoError(t, result.Source.Decode(&raw))
+	assert.Equal(t, "street", raw.Response.GeoObjectCollection.FeatureMember[0].GeoObject.MetaDataProperty.GeocoderMetaData.Kind)
+}
+
 func TestReverseGeocode(t *testing.T) {
 	ts := testServer(response2)
 	defer ts.Close()